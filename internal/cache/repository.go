@@ -0,0 +1,441 @@
+// Package cache keeps the like counts and the first pages of the liker lists in Redis in front of the match
+// repository.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+	"github.com/PatrykPasterny/dating-engine/internal/pagination"
+)
+
+const (
+	keyPrefix = "explore:"
+	// generationKey holds the generation of the cached data, which changes whenever a report may hide or show a user
+	// in the liker lists of anyone the user liked. The data cached in the previous generations is not used.
+	generationKey = keyPrefix + "generation"
+)
+
+// generations are the global generation of the cached data and the generation of the data of a single user, which
+// changes whenever the data of the user is dropped. The data read from the repository while it is dropped is cached
+// with the previous generation of the user and never used.
+type generations struct {
+	Global int64 `json:"g"`
+	User   int64 `json:"u"`
+}
+
+// cachedEntry is the cached data along with the generations it was read in.
+type cachedEntry struct {
+	Generations generations     `json:"g"`
+	Data        json.RawMessage `json:"d"`
+}
+
+// MatchRepository is the repository the cached data is read from.
+type MatchRepository interface {
+	GetLikedUser(ctx context.Context, userID string, page pagination.Page) ([]model.Match, error)
+	GetNewLikedUser(ctx context.Context, userID string, page pagination.Page) ([]model.Match, error)
+	CountLikedUser(ctx context.Context, userID string) (uint64, error)
+	MakeDecision(
+		ctx context.Context,
+		userID, recipientID string,
+		decision model.Decision,
+		superLikeLimit int64,
+	) (model.DecisionResult, error)
+	UndoDecision(ctx context.Context, userID string, window time.Duration) (model.DecisionRecord, error)
+	Unmatch(ctx context.Context, userID, matchedUserID string, reason model.UnmatchReason) error
+	BlockUser(ctx context.Context, userID, blockedUserID string) error
+	UnblockUser(ctx context.Context, userID, blockedUserID string) error
+	GetBlockedUsers(ctx context.Context, userID string) ([]model.Block, error)
+	ReportUser(ctx context.Context, report model.Report, hideThreshold int64) (model.ReportResult, error)
+	GetReports(ctx context.Context, state model.ReportState, page pagination.ReportPage) ([]model.Report, error)
+	ResolveReport(
+		ctx context.Context,
+		reportID string,
+		state model.ReportState,
+		note string,
+		hideThreshold int64,
+	) (model.ReportResult, error)
+}
+
+// Repository caches the like count and the first pages of both liker lists of every user. All the data cached
+// for a user is dropped whenever a decision involving the user is made, along with the data being read at the time,
+// the TTLs only limit how long the data may be stale when the invalidation fails. The reports, which change the lists
+// of the users unknown to the cache, outdate all the cached data at once. Redis failures are logged and the data is
+// read from the wrapped repository instead, so the cache never fails the requests.
+type Repository struct {
+	logger     *slog.Logger
+	client     redis.UniversalClient
+	repository MatchRepository
+	countTTL   time.Duration
+	pageTTL    time.Duration
+	// generationTTL keeps the generation of every user for longer than the data cached in it.
+	generationTTL time.Duration
+}
+
+func NewRepository(
+	logger *slog.Logger,
+	client redis.UniversalClient,
+	repository MatchRepository,
+	countTTL, pageTTL time.Duration,
+) *Repository {
+	return &Repository{
+		logger:     logger,
+		client:     client,
+		repository: repository,
+		countTTL:   countTTL,
+		pageTTL:    pageTTL,
+		// the data read in the generation is cached after it is read, so the generation is kept for a while longer
+		generationTTL: 2 * max(countTTL, pageTTL),
+	}
+}
+
+func (r *Repository) GetLikedUser(ctx context.Context, userID string, page pagination.Page) ([]model.Match, error) {
+	return r.getPage(ctx, "liked", userID, page, r.repository.GetLikedUser)
+}
+
+func (r *Repository) GetNewLikedUser(ctx context.Context, userID string, page pagination.Page) ([]model.Match, error) {
+	return r.getPage(ctx, "new", userID, page, r.repository.GetNewLikedUser)
+}
+
+func (r *Repository) CountLikedUser(ctx context.Context, userID string) (uint64, error) {
+	key := countKey(userID)
+
+	data, generations, err := r.read(ctx, userID, func(pipe redis.Pipeliner) *redis.StringCmd {
+		return pipe.Get(ctx, key)
+	})
+	if err == nil {
+		var count uint64
+
+		if err = json.Unmarshal(data, &count); err == nil {
+			return count, nil
+		}
+	}
+
+	r.logMiss(err, key)
+
+	count, err := r.repository.CountLikedUser(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	if data, err = encodeEntry(generations, count); err != nil {
+		return 0, err
+	}
+
+	if err = r.client.Set(ctx, key, data, r.countTTL).Err(); err != nil {
+		r.logger.Warn("failed caching like count", slog.String("key", key), slog.Any("error", err))
+	}
+
+	return count, nil
+}
+
+func (r *Repository) MakeDecision(
+	ctx context.Context,
+	userID, recipientID string,
+	decision model.Decision,
+	superLikeLimit int64,
+) (model.DecisionResult, error) {
+	result, err := r.repository.MakeDecision(ctx, userID, recipientID, decision, superLikeLimit)
+
+	// the decision may have been committed even if it failed, e.g. when the request timed out while committing
+	if invalidateErr := r.invalidate(ctx, userID, recipientID); invalidateErr != nil {
+		r.logger.Error(
+			"failed invalidating cached likes, they may be stale until they expire",
+			slog.Any("error", invalidateErr),
+		)
+	}
+
+	return result, err
+}
+
+func (r *Repository) UndoDecision(
+	ctx context.Context,
+	userID string,
+	window time.Duration,
+) (model.DecisionRecord, error) {
+	record, err := r.repository.UndoDecision(ctx, userID, window)
+
+	// the recipient is only known once the decision is undone, if the undoing failed after it was committed the
+	// cached likes of the recipient are stale until they expire
+	userIDs := []string{userID}
+	if err == nil {
+		userIDs = append(userIDs, record.RecipientUserID)
+	}
+
+	if invalidateErr := r.invalidate(ctx, userIDs...); invalidateErr != nil {
+		r.logger.Error(
+			"failed invalidating cached likes, they may be stale until they expire",
+			slog.Any("error", invalidateErr),
+		)
+	}
+
+	return record, err
+}
+
+func (r *Repository) Unmatch(
+	ctx context.Context,
+	userID, matchedUserID string,
+	reason model.UnmatchReason,
+) error {
+	err := r.repository.Unmatch(ctx, userID, matchedUserID, reason)
+
+	if invalidateErr := r.invalidate(ctx, userID, matchedUserID); invalidateErr != nil {
+		r.logger.Error(
+			"failed invalidating cached likes, they may be stale until they expire",
+			slog.Any("error", invalidateErr),
+		)
+	}
+
+	return err
+}
+
+func (r *Repository) BlockUser(ctx context.Context, userID, blockedUserID string) error {
+	err := r.repository.BlockUser(ctx, userID, blockedUserID)
+
+	if invalidateErr := r.invalidate(ctx, userID, blockedUserID); invalidateErr != nil {
+		r.logger.Error(
+			"failed invalidating cached likes, they may be stale until they expire",
+			slog.Any("error", invalidateErr),
+		)
+	}
+
+	return err
+}
+
+func (r *Repository) UnblockUser(ctx context.Context, userID, blockedUserID string) error {
+	err := r.repository.UnblockUser(ctx, userID, blockedUserID)
+
+	if invalidateErr := r.invalidate(ctx, userID, blockedUserID); invalidateErr != nil {
+		r.logger.Error(
+			"failed invalidating cached likes, they may be stale until they expire",
+			slog.Any("error", invalidateErr),
+		)
+	}
+
+	return err
+}
+
+// GetBlockedUsers is not cached, the blocks are listed rarely.
+func (r *Repository) GetBlockedUsers(ctx context.Context, userID string) ([]model.Block, error) {
+	return r.repository.GetBlockedUsers(ctx, userID)
+}
+
+// ReportUser drops the cached likes of the reporter and of the reported user, who get blocked, and moves on to the
+// next generation if the report hid the reported user from the liker lists of the others. If the reporting failed
+// after it was committed, the cached likes of the others are stale until they expire.
+func (r *Repository) ReportUser(
+	ctx context.Context,
+	report model.Report,
+	hideThreshold int64,
+) (model.ReportResult, error) {
+	result, err := r.repository.ReportUser(ctx, report, hideThreshold)
+	if err == nil && result.HiddenChanged {
+		r.nextGeneration(ctx)
+	}
+
+	if invalidateErr := r.invalidate(ctx, report.ReporterUserID, report.ReportedUserID); invalidateErr != nil {
+		r.logger.Error(
+			"failed invalidating cached likes, they may be stale until they expire",
+			slog.Any("error", invalidateErr),
+		)
+	}
+
+	return result, err
+}
+
+func (r *Repository) GetReports(
+	ctx context.Context,
+	state model.ReportState,
+	page pagination.ReportPage,
+) ([]model.Report, error) {
+	return r.repository.GetReports(ctx, state, page)
+}
+
+// ResolveReport moves on to the next generation if resolving the report showed the reported user in the liker lists
+// of the others again.
+func (r *Repository) ResolveReport(
+	ctx context.Context,
+	reportID string,
+	state model.ReportState,
+	note string,
+	hideThreshold int64,
+) (model.ReportResult, error) {
+	result, err := r.repository.ResolveReport(ctx, reportID, state, note, hideThreshold)
+	if err == nil && result.HiddenChanged {
+		r.nextGeneration(ctx)
+	}
+
+	return result, err
+}
+
+type getPageFunc func(ctx context.Context, userID string, page pagination.Page) ([]model.Match, error)
+
+// getPage caches only the first pages, the following ones depend on the position of the previous page and are
+// rarely requested twice.
+func (r *Repository) getPage(
+	ctx context.Context,
+	list, userID string,
+	page pagination.Page,
+	get getPageFunc,
+) ([]model.Match, error) {
+	if page.After != nil {
+		return get(ctx, userID, page)
+	}
+
+	key := pagesKey(userID)
+	field := fmt.Sprintf("%s:%s:%s:%d", list, page.Sort, page.Order, page.Limit)
+
+	data, generations, err := r.read(ctx, userID, func(pipe redis.Pipeliner) *redis.StringCmd {
+		return pipe.HGet(ctx, key, field)
+	})
+	if err == nil {
+		var matches []model.Match
+
+		if err = json.Unmarshal(data, &matches); err == nil {
+			return matches, nil
+		}
+	}
+
+	r.logMiss(err, key)
+
+	matches, err := get(ctx, userID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	if data, err = encodeEntry(generations, matches); err != nil {
+		return nil, err
+	}
+
+	// the expiration is refreshed with every cached page, the pages are dropped together anyway
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, field, data)
+		pipe.Expire(ctx, key, r.pageTTL)
+
+		return nil
+	})
+	if err != nil {
+		r.logger.Warn("failed caching liker page", slog.String("key", key), slog.Any("error", err))
+	}
+
+	return matches, nil
+}
+
+// read returns the data of the user cached in the current generations, the generations are returned even if the
+// data is not cached, so the data read from the repository can be cached with them. They are read before the
+// repository is, so the data read while either of them changes is cached with the previous one and never used.
+func (r *Repository) read(
+	ctx context.Context,
+	userID string,
+	get func(pipe redis.Pipeliner) *redis.StringCmd,
+) ([]byte, generations, error) {
+	var generationCmd, userGenerationCmd, dataCmd *redis.StringCmd
+
+	// the errors are read from the commands, as the pipeline fails with redis.Nil when nothing is cached
+	_, _ = r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		generationCmd = pipe.Get(ctx, generationKey)
+		userGenerationCmd = pipe.Get(ctx, userGenerationKey(userID))
+		dataCmd = get(pipe)
+
+		return nil
+	})
+
+	var (
+		current generations
+		err     error
+	)
+
+	if current.Global, err = generationCmd.Int64(); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, generations{}, fmt.Errorf("reading cache generation: %w", err)
+	}
+
+	if current.User, err = userGenerationCmd.Int64(); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, generations{}, fmt.Errorf("reading cache generation of user: %w", err)
+	}
+
+	data, err := dataCmd.Bytes()
+	if err != nil {
+		return nil, current, err
+	}
+
+	var entry cachedEntry
+
+	if err = json.Unmarshal(data, &entry); err != nil || entry.Generations != current {
+		return nil, current, redis.Nil
+	}
+
+	return entry.Data, current, nil
+}
+
+// nextGeneration makes all the cached data outdated, which is the only way to drop the likes of the users the
+// reported user liked, as they are not known to the cache.
+func (r *Repository) nextGeneration(ctx context.Context) {
+	if err := r.client.Incr(context.WithoutCancel(ctx), generationKey).Err(); err != nil {
+		r.logger.Error(
+			"failed changing cache generation, cached likes may be stale until they expire",
+			slog.Any("error", err),
+		)
+	}
+}
+
+func encodeEntry(generations generations, value any) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("encoding cached value: %w", err)
+	}
+
+	if data, err = json.Marshal(cachedEntry{Generations: generations, Data: data}); err != nil {
+		return nil, fmt.Errorf("encoding cached entry: %w", err)
+	}
+
+	return data, nil
+}
+
+// invalidate drops all the data cached for both users, as the decision changes the lists of both of them, and moves
+// both users on to their next generation, so the data being read for them is not cached for good.
+func (r *Repository) invalidate(ctx context.Context, userIDs ...string) error {
+	// the invalidation has to happen even if the request has been canceled in the meantime
+	ctx = context.WithoutCancel(ctx)
+
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, userID := range userIDs {
+			pipe.Del(ctx, countKey(userID), pagesKey(userID))
+			pipe.Incr(ctx, userGenerationKey(userID))
+			pipe.Expire(ctx, userGenerationKey(userID), r.generationTTL)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("deleting cached keys: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) logMiss(err error, key string) {
+	if errors.Is(err, redis.Nil) {
+		return
+	}
+
+	r.logger.Warn("failed reading cache, falling back to repository", slog.String("key", key), slog.Any("error", err))
+}
+
+func countKey(userID string) string {
+	return keyPrefix + userID + ":count"
+}
+
+func pagesKey(userID string) string {
+	return keyPrefix + userID + ":pages"
+}
+
+func userGenerationKey(userID string) string {
+	return keyPrefix + userID + ":generation"
+}
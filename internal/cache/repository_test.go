@@ -0,0 +1,351 @@
+package cache_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/PatrykPasterny/dating-engine/internal/cache"
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+	"github.com/PatrykPasterny/dating-engine/internal/pagination"
+	"github.com/PatrykPasterny/dating-engine/internal/repository"
+	"github.com/PatrykPasterny/dating-engine/internal/repository/repositorytest"
+)
+
+const ttl = time.Minute
+
+var firstPage = pagination.Page{Sort: pagination.SortByTime, Order: pagination.Descending, Limit: 10}
+
+// countingRepository counts the reads that reached the repository behind the cache.
+type countingRepository struct {
+	*repository.MemoryRepository
+	reads atomic.Int64
+	// afterCount runs once the like count is read, before it is returned.
+	afterCount func()
+}
+
+func (cr *countingRepository) GetLikedUser(
+	ctx context.Context,
+	userID string,
+	page pagination.Page,
+) ([]model.Match, error) {
+	cr.reads.Add(1)
+
+	return cr.MemoryRepository.GetLikedUser(ctx, userID, page)
+}
+
+func (cr *countingRepository) CountLikedUser(ctx context.Context, userID string) (uint64, error) {
+	cr.reads.Add(1)
+
+	count, err := cr.MemoryRepository.CountLikedUser(ctx, userID)
+
+	if cr.afterCount != nil {
+		cr.afterCount()
+	}
+
+	return count, err
+}
+
+func newRepository(t *testing.T) (*cache.Repository, *countingRepository, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+
+	client := redis.NewClient(&redis.Options{
+		Addr:        server.Addr(),
+		DialTimeout: 100 * time.Millisecond,
+		MaxRetries:  -1,
+	})
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	inner := &countingRepository{MemoryRepository: repository.NewMemoryRepository()}
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	return cache.NewRepository(logger, client, inner, ttl, ttl), inner, server
+}
+
+func newUserIDs(count int) []string {
+	userIDs := make([]string, 0, count)
+
+	for range count {
+		userIDs = append(userIDs, uuid.NewString())
+	}
+
+	return userIDs
+}
+
+func TestRepository(t *testing.T) {
+	suite.Run(t, &repositorytest.ConformanceSuite{
+		NewRepository: func() repositorytest.Repository {
+			repo, _, _ := newRepository(t)
+
+			return repo
+		},
+	})
+}
+
+func TestCachesCountAndFirstPage(t *testing.T) {
+	ctx := context.Background()
+	repo, inner, server := newRepository(t)
+	userIDs := newUserIDs(2)
+
+	_, err := repo.MakeDecision(ctx, userIDs[0], userIDs[1], model.DecisionLike, 1)
+	require.NoError(t, err)
+
+	for range 3 {
+		count, err := repo.CountLikedUser(ctx, userIDs[1])
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), count)
+
+		likers, err := repo.GetLikedUser(ctx, userIDs[1], firstPage)
+		require.NoError(t, err)
+		require.Len(t, likers, 1)
+	}
+
+	require.Equal(t, int64(2), inner.reads.Load())
+
+	server.FastForward(ttl)
+
+	_, err = repo.CountLikedUser(ctx, userIDs[1])
+	require.NoError(t, err)
+	require.Equal(t, int64(3), inner.reads.Load())
+}
+
+func TestDoesNotCacheFollowingPages(t *testing.T) {
+	ctx := context.Background()
+	repo, inner, _ := newRepository(t)
+	userIDs := newUserIDs(1)
+
+	page := firstPage
+	page.After = &pagination.Position{LikedAt: time.Now(), ActorUserID: uuid.NewString()}
+
+	for range 2 {
+		_, err := repo.GetLikedUser(ctx, userIDs[0], page)
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, int64(2), inner.reads.Load())
+}
+
+func TestDecisionInvalidatesBothUsers(t *testing.T) {
+	ctx := context.Background()
+	repo, _, _ := newRepository(t)
+	userIDs := newUserIDs(2)
+
+	_, err := repo.MakeDecision(ctx, userIDs[0], userIDs[1], model.DecisionLike, 1)
+	require.NoError(t, err)
+
+	for _, userID := range userIDs {
+		_, err = repo.CountLikedUser(ctx, userID)
+		require.NoError(t, err)
+
+		_, err = repo.GetLikedUser(ctx, userID, firstPage)
+		require.NoError(t, err)
+	}
+
+	result, err := repo.MakeDecision(ctx, userIDs[1], userIDs[0], model.DecisionLike, 1)
+	require.NoError(t, err)
+	require.True(t, result.MutualLikes)
+
+	count, err := repo.CountLikedUser(ctx, userIDs[0])
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), count)
+
+	likers, err := repo.GetLikedUser(ctx, userIDs[1], firstPage)
+	require.NoError(t, err)
+	require.Len(t, likers, 1)
+	require.True(t, likers[0].Matched)
+}
+
+func TestDecisionDuringReadOutdatesReadData(t *testing.T) {
+	ctx := context.Background()
+	repo, inner, _ := newRepository(t)
+	userIDs := newUserIDs(2)
+
+	// the like is made and its invalidation done after the count is read, but before it is cached
+	inner.afterCount = func() {
+		inner.afterCount = nil
+
+		_, err := repo.MakeDecision(ctx, userIDs[0], userIDs[1], model.DecisionLike, 1)
+		require.NoError(t, err)
+	}
+
+	count, err := repo.CountLikedUser(ctx, userIDs[1])
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	count, err = repo.CountLikedUser(ctx, userIDs[1])
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), count)
+	require.Equal(t, int64(2), inner.reads.Load())
+}
+
+func TestUndoInvalidatesBothUsers(t *testing.T) {
+	ctx := context.Background()
+	repo, _, _ := newRepository(t)
+	userIDs := newUserIDs(2)
+
+	_, err := repo.MakeDecision(ctx, userIDs[0], userIDs[1], model.DecisionLike, 1)
+	require.NoError(t, err)
+
+	_, err = repo.MakeDecision(ctx, userIDs[1], userIDs[0], model.DecisionLike, 1)
+	require.NoError(t, err)
+
+	for _, userID := range userIDs {
+		_, err = repo.CountLikedUser(ctx, userID)
+		require.NoError(t, err)
+
+		_, err = repo.GetLikedUser(ctx, userID, firstPage)
+		require.NoError(t, err)
+	}
+
+	_, err = repo.UndoDecision(ctx, userIDs[1], time.Minute)
+	require.NoError(t, err)
+
+	count, err := repo.CountLikedUser(ctx, userIDs[0])
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	likers, err := repo.GetLikedUser(ctx, userIDs[1], firstPage)
+	require.NoError(t, err)
+	require.Len(t, likers, 1)
+	require.False(t, likers[0].Matched)
+}
+
+func TestUnmatchInvalidatesBothUsers(t *testing.T) {
+	ctx := context.Background()
+	repo, _, _ := newRepository(t)
+	userIDs := newUserIDs(2)
+
+	_, err := repo.MakeDecision(ctx, userIDs[0], userIDs[1], model.DecisionLike, 1)
+	require.NoError(t, err)
+
+	_, err = repo.MakeDecision(ctx, userIDs[1], userIDs[0], model.DecisionLike, 1)
+	require.NoError(t, err)
+
+	for _, userID := range userIDs {
+		_, err = repo.CountLikedUser(ctx, userID)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, repo.Unmatch(ctx, userIDs[0], userIDs[1], model.UnmatchReasonOther))
+
+	for _, userID := range userIDs {
+		count, err := repo.CountLikedUser(ctx, userID)
+		require.NoError(t, err)
+		require.Zero(t, count)
+	}
+}
+
+func TestBlockInvalidatesBothUsers(t *testing.T) {
+	ctx := context.Background()
+	repo, _, _ := newRepository(t)
+	userIDs := newUserIDs(2)
+
+	_, err := repo.MakeDecision(ctx, userIDs[0], userIDs[1], model.DecisionLike, 1)
+	require.NoError(t, err)
+
+	_, err = repo.CountLikedUser(ctx, userIDs[1])
+	require.NoError(t, err)
+
+	require.NoError(t, repo.BlockUser(ctx, userIDs[1], userIDs[0]))
+
+	count, err := repo.CountLikedUser(ctx, userIDs[1])
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	require.NoError(t, repo.UnblockUser(ctx, userIDs[1], userIDs[0]))
+
+	count, err = repo.CountLikedUser(ctx, userIDs[1])
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), count)
+}
+
+func TestReportOutdatesCachedLikesOfOtherUsers(t *testing.T) {
+	ctx := context.Background()
+	repo, inner, _ := newRepository(t)
+	userIDs := newUserIDs(3)
+
+	_, err := repo.MakeDecision(ctx, userIDs[0], userIDs[1], model.DecisionLike, 1)
+	require.NoError(t, err)
+
+	count, err := repo.CountLikedUser(ctx, userIDs[1])
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), count)
+
+	_, err = repo.ReportUser(ctx, model.Report{
+		ReporterUserID: userIDs[2],
+		ReportedUserID: userIDs[0],
+		Reason:         model.ReportReasonFakeProfile,
+	}, 1)
+	require.NoError(t, err)
+
+	count, err = repo.CountLikedUser(ctx, userIDs[1])
+	require.NoError(t, err)
+	require.Zero(t, count)
+	require.Equal(t, int64(2), inner.reads.Load())
+
+	count, err = repo.CountLikedUser(ctx, userIDs[1])
+	require.NoError(t, err)
+	require.Zero(t, count)
+	require.Equal(t, int64(2), inner.reads.Load())
+}
+
+func TestReportBelowThresholdKeepsCachedLikesOfOtherUsers(t *testing.T) {
+	ctx := context.Background()
+	repo, inner, _ := newRepository(t)
+	userIDs := newUserIDs(3)
+
+	_, err := repo.MakeDecision(ctx, userIDs[0], userIDs[1], model.DecisionLike, 1)
+	require.NoError(t, err)
+
+	count, err := repo.CountLikedUser(ctx, userIDs[1])
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), count)
+
+	result, err := repo.ReportUser(ctx, model.Report{
+		ReporterUserID: userIDs[2],
+		ReportedUserID: userIDs[0],
+		Reason:         model.ReportReasonFakeProfile,
+	}, 2)
+	require.NoError(t, err)
+	require.False(t, result.HiddenChanged)
+
+	count, err = repo.CountLikedUser(ctx, userIDs[1])
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), count)
+	require.Equal(t, int64(1), inner.reads.Load())
+}
+
+func TestFallsBackToRepositoryWhenRedisIsDown(t *testing.T) {
+	ctx := context.Background()
+	repo, inner, server := newRepository(t)
+	userIDs := newUserIDs(2)
+
+	server.Close()
+
+	result, err := repo.MakeDecision(ctx, userIDs[0], userIDs[1], model.DecisionLike, 1)
+	require.NoError(t, err)
+	require.False(t, result.MutualLikes)
+
+	count, err := repo.CountLikedUser(ctx, userIDs[1])
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), count)
+
+	likers, err := repo.GetLikedUser(ctx, userIDs[1], firstPage)
+	require.NoError(t, err)
+	require.Len(t, likers, 1)
+
+	require.Equal(t, int64(2), inner.reads.Load())
+}
@@ -1,35 +1,263 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"log/slog"
 	"os"
+	"time"
 
 	"gopkg.in/yaml.v2"
+
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+	"github.com/PatrykPasterny/dating-engine/internal/outbox"
+)
+
+const (
+	// DatabaseDriverMongo stores the matches in MongoDB.
+	DatabaseDriverMongo = "mongo"
+	// DatabaseDriverMemory keeps the matches in memory, it is meant for tests and local development only.
+	DatabaseDriverMemory = "memory"
 )
 
+// DefaultPath is the configuration file read when no other file is selected. Unlike a selected file,
+// it does not have to exist.
+const DefaultPath = "./internal/config/config.yml"
+
+// Fields tagged with redact are hidden when the configuration is logged: "all" hides the whole value and
+// "password" only the password of a URI. Fields tagged with reload can be changed while the service is running,
+// see Watcher.
 type Config struct {
-	Server struct {
+	// Path is the file the configuration was read from, it is empty if no file was read.
+	Path string `yaml:"-"`
+	// LogLevel is the minimum level of the logged messages: debug, info, warn or error.
+	LogLevel string `yaml:"logLevel" reload:"true"`
+	Logging  struct {
+		// SuccessSampleRatio is the fraction of the successfully handled requests that are logged, the failed ones
+		// are always logged.
+		SuccessSampleRatio float64 `yaml:"successSampleRatio" reload:"true"`
+		// HashUserIDs logs the hashes of the user IDs instead of the IDs themselves.
+		HashUserIDs bool `yaml:"hashUserIDs" reload:"true"`
+	} `yaml:"logging"`
+	// ReloadInterval is how often the file is checked for changes, zero disables the checks.
+	ReloadInterval time.Duration `yaml:"reloadInterval"`
+	Server         struct {
 		Host string `yaml:"host"`
 		Port string `yaml:"port"`
+		// ShutdownTimeout is how long the in-flight requests are waited for when the server stops.
+		ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
 	} `yaml:"server"`
+	Admin struct {
+		// Enabled serves the moderation and webhook services on the port of the same host as the server, apart from
+		// the explore service. The admin services do not authenticate the callers, so the port must not be reachable
+		// publicly.
+		Enabled bool   `yaml:"enabled"`
+		Port    string `yaml:"port"`
+	} `yaml:"admin"`
 	Database struct {
-		URI        string `yaml:"uri"`
+		Driver     string `yaml:"driver"`
+		URI        string `yaml:"uri" redact:"password"`
 		Name       string `yaml:"name"`
 		Collection string `yaml:"collection"`
+		// SuperLikesCollection counts the super likes every user made per day.
+		SuperLikesCollection string `yaml:"superLikesCollection"`
+		// DecisionsCollection keeps the history of the decisions, which allows undoing them.
+		DecisionsCollection string `yaml:"decisionsCollection"`
+		// BlocksCollection keeps the users every user blocked.
+		BlocksCollection string `yaml:"blocksCollection"`
+		// ReportsCollection keeps the reports of the users for the moderators.
+		ReportsCollection string `yaml:"reportsCollection"`
+		// ReportedUsersCollection keeps whether the reports hide the reported users.
+		ReportedUsersCollection string `yaml:"reportedUsersCollection"`
+		// EventsCollection receives the events of the changes of the likes, which are watched by the clients.
+		EventsCollection string `yaml:"eventsCollection"`
+		// OutboxCollection receives the domain events published to the other services by the outbox relay.
+		OutboxCollection string `yaml:"outboxCollection"`
+		// OutboxSequencesCollection numbers the outbox events of every pair of users.
+		OutboxSequencesCollection string `yaml:"outboxSequencesCollection"`
+		// OutboxLeasesCollection keeps which instance of the service publishes the outbox events.
+		OutboxLeasesCollection string `yaml:"outboxLeasesCollection"`
+		// DeadLettersCollection keeps the webhook deliveries that failed for good until they are replayed.
+		DeadLettersCollection string `yaml:"deadLettersCollection"`
 	} `yaml:"database"`
+	Health struct {
+		// Interval is how often the database is pinged to report the serving status of the service.
+		Interval time.Duration `yaml:"interval"`
+		// Timeout is how long a single ping may take before the database is considered unavailable.
+		Timeout time.Duration `yaml:"timeout"`
+	} `yaml:"health"`
+	Metrics struct {
+		// Enabled exposes the Prometheus metrics on the /metrics path of the port.
+		Enabled bool   `yaml:"enabled"`
+		Port    string `yaml:"port"`
+	} `yaml:"metrics"`
+	Tracing struct {
+		// Exporter is where the spans are sent: none, stdout or otlp.
+		Exporter string `yaml:"exporter"`
+		// Endpoint is the address of the OTLP collector.
+		Endpoint string `yaml:"endpoint"`
+		// SampleRatio is the fraction of the traces started by the service that are recorded.
+		SampleRatio float64 `yaml:"sampleRatio"`
+	} `yaml:"tracing"`
 	Redis struct {
-		URI      string `yaml:"uri"`
-		Password string `yaml:"password"`
+		// Enabled caches the like counts and the first pages of the liker lists in Redis.
+		Enabled  bool   `yaml:"enabled"`
+		URI      string `yaml:"uri" redact:"password"`
+		Password string `yaml:"password" redact:"all"`
 		Database int    `yaml:"database"`
-	}
-	PageSize int64 `yaml:"pageSize"`
+		// Timeout limits every Redis call, so the requests fall back to the database quickly when Redis is down.
+		Timeout  time.Duration `yaml:"timeout"`
+		CountTTL time.Duration `yaml:"countTTL"`
+		PageTTL  time.Duration `yaml:"pageTTL"`
+	} `yaml:"redis"`
+	Pagination struct {
+		Secret string `yaml:"secret" redact:"all"`
+		Sort   string `yaml:"sort" reload:"true"`
+		Order  string `yaml:"order" reload:"true"`
+	} `yaml:"pagination"`
+	SuperLikes struct {
+		// DailyLimit is how many users a user can super like per day, zero disables the super likes.
+		DailyLimit int64 `yaml:"dailyLimit" reload:"true"`
+	} `yaml:"superLikes"`
+	Undo struct {
+		// Window is how long after making the decision the user can undo it, zero disables undoing.
+		Window time.Duration `yaml:"window" reload:"true"`
+	} `yaml:"undo"`
+	Reports struct {
+		// HideThreshold is how many users have to report a user to hide the user from the liker lists of the others
+		// until the reports are reviewed, zero never hides anyone.
+		HideThreshold int64 `yaml:"hideThreshold" reload:"true"`
+	} `yaml:"reports"`
+	Outbox struct {
+		// Enabled runs the relay publishing the outbox events, the events are written to the outbox either way.
+		Enabled bool `yaml:"enabled"`
+		// Publisher is where the events are published: stdout or file appending them to the path for local use, or
+		// webhook. It has no default, as the events are marked published once the publisher takes them.
+		Publisher string `yaml:"publisher"`
+		Path      string `yaml:"path"`
+		// Interval is how often the pending events are published.
+		Interval time.Duration `yaml:"interval"`
+		// BatchSize is how many of the oldest pending events are published at a time, together with the later
+		// events of their pairs.
+		BatchSize int64 `yaml:"batchSize"`
+		// LeaseDuration is how long the instance publishing the events keeps publishing them after it stops
+		// extending the lease, e.g. because it crashed, before another instance takes over.
+		LeaseDuration time.Duration `yaml:"leaseDuration"`
+		Webhook       struct {
+			// Endpoints are the comma separated URLs the events of every type are posted to, the events of the types
+			// without any endpoints are not delivered.
+			Endpoints struct {
+				LikeCreated    string `yaml:"likeCreated"`
+				MatchCreated   string `yaml:"matchCreated"`
+				MatchDissolved string `yaml:"matchDissolved"`
+			} `yaml:"endpoints"`
+			// Secret signs the payloads with HMAC-SHA256.
+			Secret string `yaml:"secret" redact:"all"`
+			// Timeout limits every attempt of the delivery.
+			Timeout time.Duration `yaml:"timeout"`
+			// MaxAttempts is how many times the delivery is attempted, once per round of the relay, before it is moved
+			// to the dead letters. The backoff between the attempts starts at InitialBackoff and doubles up to
+			// MaxBackoff, the events of the pair wait for it, but the other pairs do not.
+			MaxAttempts    int64         `yaml:"maxAttempts"`
+			InitialBackoff time.Duration `yaml:"initialBackoff"`
+			MaxBackoff     time.Duration `yaml:"maxBackoff"`
+		} `yaml:"webhook"`
+	} `yaml:"outbox"`
+	PageSize    int64 `yaml:"pageSize" reload:"true"`
+	MaxPageSize int64 `yaml:"maxPageSize" reload:"true"`
 }
 
+// Default returns the configuration the service runs with when nothing overrides it.
+func Default() *Config {
+	var cfg Config
+
+	cfg.LogLevel = "info"
+	cfg.Logging.SuccessSampleRatio = 1
+	cfg.ReloadInterval = 5 * time.Second
+	cfg.Server.Port = "8080"
+	cfg.Server.ShutdownTimeout = 10 * time.Second
+	cfg.Admin.Port = "8081"
+	cfg.Database.Driver = DatabaseDriverMongo
+	cfg.Database.URI = "mongodb://localhost:27017/?directConnection=true"
+	cfg.Database.Name = "db"
+	cfg.Database.Collection = "matches"
+	cfg.Database.SuperLikesCollection = "superLikes"
+	cfg.Database.DecisionsCollection = "decisions"
+	cfg.Database.BlocksCollection = "blocks"
+	cfg.Database.ReportsCollection = "reports"
+	cfg.Database.ReportedUsersCollection = "reportedUsers"
+	cfg.Database.EventsCollection = "events"
+	cfg.Database.OutboxCollection = "outbox"
+	cfg.Database.OutboxSequencesCollection = "outboxSequences"
+	cfg.Database.OutboxLeasesCollection = "outboxLeases"
+	cfg.Database.DeadLettersCollection = "deadLetters"
+	cfg.Health.Interval = 5 * time.Second
+	cfg.Health.Timeout = 2 * time.Second
+	cfg.Metrics.Enabled = true
+	cfg.Metrics.Port = "9090"
+	cfg.Tracing.Exporter = "none"
+	cfg.Tracing.Endpoint = "localhost:4317"
+	cfg.Tracing.SampleRatio = 1
+	cfg.Redis.URI = "localhost:6379"
+	cfg.Redis.Timeout = 100 * time.Millisecond
+	cfg.Redis.CountTTL = time.Minute
+	cfg.Redis.PageTTL = time.Minute
+	cfg.Pagination.Sort = "time"
+	cfg.Pagination.Order = "desc"
+	cfg.SuperLikes.DailyLimit = 5
+	cfg.Undo.Window = time.Minute
+	cfg.Reports.HideThreshold = 3
+	cfg.Outbox.Path = "outbox.jsonl"
+	cfg.Outbox.Interval = time.Second
+	cfg.Outbox.BatchSize = 100
+	cfg.Outbox.LeaseDuration = 30 * time.Second
+	cfg.Outbox.Webhook.Timeout = 2 * time.Second
+	cfg.Outbox.Webhook.MaxAttempts = 4
+	cfg.Outbox.Webhook.InitialBackoff = 100 * time.Millisecond
+	cfg.Outbox.Webhook.MaxBackoff = time.Second
+	cfg.PageSize = 20
+	cfg.MaxPageSize = 100
+
+	return &cfg
+}
+
+// GetConfig reads the configuration file on top of the defaults.
 func GetConfig(path string) (*Config, error) {
+	cfg := Default()
+
+	if err := decodeFile(path, cfg); err != nil {
+		return nil, err
+	}
+
+	cfg.Path = path
+
+	return cfg, nil
+}
+
+// WebhookEndpoints returns the parsed endpoints of the webhooks by the type of the events delivered to them.
+func (c *Config) WebhookEndpoints() map[model.OutboxEventType][]string {
+	return map[model.OutboxEventType][]string{
+		model.OutboxEventLikeCreated:    outbox.ParseEndpoints(c.Outbox.Webhook.Endpoints.LikeCreated),
+		model.OutboxEventMatchCreated:   outbox.ParseEndpoints(c.Outbox.Webhook.Endpoints.MatchCreated),
+		model.OutboxEventMatchDissolved: outbox.ParseEndpoints(c.Outbox.Webhook.Endpoints.MatchDissolved),
+	}
+}
+
+// Level returns the parsed LogLevel, the level is validated when the configuration is loaded.
+func (c *Config) Level() slog.Level {
+	var level slog.Level
+
+	_ = level.UnmarshalText([]byte(c.LogLevel))
+
+	return level
+}
+
+func decodeFile(path string, cfg *Config) error {
 	f, err := os.Open(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed opening config file: %w", err)
+		return fmt.Errorf("failed opening config file: %w", err)
 	}
 	defer func() {
 		if err = f.Close(); err != nil {
@@ -37,13 +265,11 @@ func GetConfig(path string) (*Config, error) {
 		}
 	}()
 
-	var cfg Config
-
 	decoder := yaml.NewDecoder(f)
 
-	if err = decoder.Decode(&cfg); err != nil {
-		return nil, fmt.Errorf("failed opening config file: %w", err)
+	if err = decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed decoding config file: %w", err)
 	}
 
-	return &cfg, err
+	return nil
 }
@@ -0,0 +1,223 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// EnvPrefix starts the names of all environment variables overriding the configuration.
+	EnvPrefix = "EXPLORE_"
+
+	configFlag = "config"
+	configEnv  = EnvPrefix + "CONFIG"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setting is a single configurable value, addressed by the dotted path of its YAML keys, e.g. database.uri.
+type setting struct {
+	key    string
+	redact string
+	reload bool
+	value  reflect.Value
+}
+
+type override struct {
+	key   string
+	value string
+}
+
+// Load builds the configuration in layers, each of them overriding the previous one:
+//   - the defaults,
+//   - the YAML file selected by the -config flag or the EXPLORE_CONFIG variable, DefaultPath otherwise,
+//   - the environment variables, e.g. EXPLORE_DATABASE_URI for database.uri,
+//   - the command line flags, e.g. -database.uri.
+//
+// The loaded configuration is validated. The environment is read using lookupEnv, which is os.LookupEnv outside
+// of tests. It returns flag.ErrHelp if the usage was requested with -h.
+func Load(args []string, lookupEnv func(string) (string, bool)) (*Config, error) {
+	cfg := Default()
+	settings := settingsOf(cfg)
+
+	flagSet := flag.NewFlagSet("explore", flag.ContinueOnError)
+	configPath := flagSet.String(configFlag, "", fmt.Sprintf("path of the YAML config file (env %s)", configEnv))
+
+	var flagOverrides []override
+
+	for _, s := range settings {
+		key := s.key
+
+		usage := fmt.Sprintf("overrides %s (env %s)", key, envName(key))
+		set := func(value string) error {
+			flagOverrides = append(flagOverrides, override{key: key, value: value})
+
+			return nil
+		}
+
+		if s.value.Kind() == reflect.Bool {
+			flagSet.BoolFunc(key, usage, set)
+		} else {
+			flagSet.Func(key, usage, set)
+		}
+	}
+
+	if err := flagSet.Parse(args); err != nil {
+		return nil, fmt.Errorf("failed parsing flags: %w", err)
+	}
+
+	path, selected := *configPath, *configPath != ""
+	if !selected {
+		path, selected = lookupEnv(configEnv)
+	}
+
+	if !selected {
+		path = DefaultPath
+	}
+
+	switch err := decodeFile(path, cfg); {
+	case err == nil:
+		cfg.Path = path
+	case selected || !errors.Is(err, fs.ErrNotExist):
+		return nil, err
+	}
+
+	byKey := make(map[string]setting, len(settings))
+
+	for _, s := range settings {
+		byKey[s.key] = s
+
+		if value, ok := lookupEnv(envName(s.key)); ok {
+			if err := setValue(s.value, value); err != nil {
+				return nil, fmt.Errorf("failed reading %s: %w", envName(s.key), err)
+			}
+		}
+	}
+
+	for _, o := range flagOverrides {
+		if err := setValue(byKey[o.key].value, o.value); err != nil {
+			return nil, fmt.Errorf("failed reading -%s flag: %w", o.key, err)
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// settingsOf lists all the leaf fields of the configuration, pointing to their values in cfg.
+func settingsOf(cfg *Config) []setting {
+	return appendSettings(nil, "", reflect.ValueOf(cfg).Elem())
+}
+
+func appendSettings(settings []setting, prefix string, v reflect.Value) []setting {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+
+		key := strings.Split(field.Tag.Get("yaml"), ",")[0]
+
+		switch key {
+		case "-":
+			continue
+		case "":
+			key = strings.ToLower(field.Name)
+		}
+
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		if field.Type.Kind() == reflect.Struct && field.Type != durationType {
+			settings = appendSettings(settings, key, v.Field(i))
+
+			continue
+		}
+
+		settings = append(settings, setting{
+			key:    key,
+			redact: field.Tag.Get("redact"),
+			reload: field.Tag.Get("reload") == "true",
+			value:  v.Field(i),
+		})
+	}
+
+	return settings
+}
+
+// envName converts the key to the environment variable overriding it, e.g. server.shutdownTimeout
+// to EXPLORE_SERVER_SHUTDOWN_TIMEOUT and redis.countTTL to EXPLORE_REDIS_COUNT_TTL.
+func envName(key string) string {
+	var b strings.Builder
+
+	b.WriteString(EnvPrefix)
+
+	var previous rune
+
+	for _, r := range key {
+		switch {
+		case r == '.':
+			b.WriteByte('_')
+		case unicode.IsUpper(r) && unicode.IsLower(previous):
+			b.WriteByte('_')
+			b.WriteRune(r)
+		default:
+			b.WriteRune(unicode.ToUpper(r))
+		}
+
+		previous = r
+	}
+
+	return b.String()
+}
+
+func setValue(v reflect.Value, value string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(int64(d))
+
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+
+		v.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+
+		v.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
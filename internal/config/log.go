@@ -0,0 +1,86 @@
+package config
+
+import (
+	"log/slog"
+	"net/url"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// LogValue groups the settings the same way the YAML file does, hiding the secrets.
+func (c *Config) LogValue() slog.Value {
+	root := &logGroup{}
+
+	for _, s := range settingsOf(c) {
+		group := root
+		keys := strings.Split(s.key, ".")
+
+		for _, key := range keys[:len(keys)-1] {
+			group = group.child(key)
+		}
+
+		group.attrs = append(group.attrs, slog.Any(keys[len(keys)-1], redactValue(s)))
+	}
+
+	return root.value()
+}
+
+type logGroup struct {
+	name     string
+	attrs    []slog.Attr
+	children []*logGroup
+}
+
+func (g *logGroup) child(name string) *logGroup {
+	for _, c := range g.children {
+		if c.name == name {
+			return c
+		}
+	}
+
+	c := &logGroup{name: name}
+	g.children = append(g.children, c)
+
+	return c
+}
+
+func (g *logGroup) value() slog.Value {
+	attrs := make([]slog.Attr, 0, len(g.children)+len(g.attrs))
+
+	for _, c := range g.children {
+		attrs = append(attrs, slog.Attr{Key: c.name, Value: c.value()})
+	}
+
+	return slog.GroupValue(append(attrs, g.attrs...)...)
+}
+
+func redactValue(s setting) any {
+	switch s.redact {
+	case "all":
+		if s.value.IsZero() {
+			return ""
+		}
+
+		return redacted
+	case "password":
+		return redactURI(s.value.String())
+	default:
+		return s.value.Interface()
+	}
+}
+
+// redactURI hides the password of the URI. The URIs listing multiple hosts cannot always be parsed,
+// those are hidden completely if they carry any credentials.
+func redactURI(uri string) string {
+	u, err := url.Parse(uri)
+	if err == nil {
+		return u.Redacted()
+	}
+
+	if strings.Contains(uri, "@") {
+		return redacted
+	}
+
+	return uri
+}
@@ -0,0 +1,260 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/PatrykPasterny/dating-engine/internal/outbox"
+	"github.com/PatrykPasterny/dating-engine/internal/pagination"
+	"github.com/PatrykPasterny/dating-engine/internal/tracing"
+)
+
+// Validate reports all the settings the service cannot run with.
+func (c *Config) Validate() error {
+	var errs []error
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		errs = append(errs, fmt.Errorf("logLevel: %w", err))
+	}
+
+	if c.Logging.SuccessSampleRatio < 0 || c.Logging.SuccessSampleRatio > 1 {
+		errs = append(errs, errors.New("logging.successSampleRatio has to be between 0 and 1"))
+	}
+
+	if c.ReloadInterval < 0 {
+		errs = append(errs, errors.New("reloadInterval cannot be negative"))
+	}
+
+	if c.Server.Port == "" {
+		errs = append(errs, errors.New("server.port is required"))
+	}
+
+	if c.Server.ShutdownTimeout < 0 {
+		errs = append(errs, errors.New("server.shutdownTimeout cannot be negative"))
+	}
+
+	if c.Admin.Enabled {
+		if c.Admin.Port == "" {
+			errs = append(errs, errors.New("admin.port is required"))
+		}
+
+		if c.Admin.Port == c.Server.Port {
+			errs = append(errs, errors.New("admin.port has to differ from server.port"))
+		}
+	}
+
+	switch c.Database.Driver {
+	case DatabaseDriverMemory:
+	case DatabaseDriverMongo:
+		if c.Database.URI == "" {
+			errs = append(errs, errors.New("database.uri is required"))
+		}
+
+		if c.Database.Name == "" {
+			errs = append(errs, errors.New("database.name is required"))
+		}
+
+		if c.Database.Collection == "" {
+			errs = append(errs, errors.New("database.collection is required"))
+		}
+
+		if c.Database.SuperLikesCollection == "" {
+			errs = append(errs, errors.New("database.superLikesCollection is required"))
+		}
+
+		if c.Database.DecisionsCollection == "" {
+			errs = append(errs, errors.New("database.decisionsCollection is required"))
+		}
+
+		if c.Database.BlocksCollection == "" {
+			errs = append(errs, errors.New("database.blocksCollection is required"))
+		}
+
+		if c.Database.ReportsCollection == "" {
+			errs = append(errs, errors.New("database.reportsCollection is required"))
+		}
+
+		if c.Database.ReportedUsersCollection == "" {
+			errs = append(errs, errors.New("database.reportedUsersCollection is required"))
+		}
+
+		if c.Database.EventsCollection == "" {
+			errs = append(errs, errors.New("database.eventsCollection is required"))
+		}
+
+		if c.Database.OutboxCollection == "" {
+			errs = append(errs, errors.New("database.outboxCollection is required"))
+		}
+
+		if c.Database.OutboxSequencesCollection == "" {
+			errs = append(errs, errors.New("database.outboxSequencesCollection is required"))
+		}
+
+		if c.Database.OutboxLeasesCollection == "" {
+			errs = append(errs, errors.New("database.outboxLeasesCollection is required"))
+		}
+
+		if c.Database.DeadLettersCollection == "" {
+			errs = append(errs, errors.New("database.deadLettersCollection is required"))
+		}
+
+		if c.Health.Interval <= 0 {
+			errs = append(errs, errors.New("health.interval has to be positive"))
+		}
+
+		if c.Health.Timeout <= 0 {
+			errs = append(errs, errors.New("health.timeout has to be positive"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("database.driver: unknown driver %q", c.Database.Driver))
+	}
+
+	if c.Metrics.Enabled && c.Metrics.Port == "" {
+		errs = append(errs, errors.New("metrics.port is required"))
+	}
+
+	switch c.Tracing.Exporter {
+	case tracing.ExporterNone, tracing.ExporterStdout:
+	case tracing.ExporterOTLP:
+		if c.Tracing.Endpoint == "" {
+			errs = append(errs, errors.New("tracing.endpoint is required"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter: unknown exporter %q", c.Tracing.Exporter))
+	}
+
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs = append(errs, errors.New("tracing.sampleRatio has to be between 0 and 1"))
+	}
+
+	if c.Redis.Enabled {
+		if c.Redis.URI == "" {
+			errs = append(errs, errors.New("redis.uri is required"))
+		}
+
+		if c.Redis.Timeout <= 0 {
+			errs = append(errs, errors.New("redis.timeout has to be positive"))
+		}
+
+		if c.Redis.CountTTL <= 0 || c.Redis.PageTTL <= 0 {
+			errs = append(errs, errors.New("redis.countTTL and redis.pageTTL have to be positive"))
+		}
+	}
+
+	if err := pagination.Sort(c.Pagination.Sort).Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("pagination.sort: %w", err))
+	}
+
+	if err := pagination.Order(c.Pagination.Order).Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("pagination.order: %w", err))
+	}
+
+	if c.SuperLikes.DailyLimit < 0 {
+		errs = append(errs, errors.New("superLikes.dailyLimit cannot be negative"))
+	}
+
+	if c.Undo.Window < 0 {
+		errs = append(errs, errors.New("undo.window cannot be negative"))
+	}
+
+	if c.Reports.HideThreshold < 0 {
+		errs = append(errs, errors.New("reports.hideThreshold cannot be negative"))
+	}
+
+	if c.Outbox.Enabled {
+		switch c.Outbox.Publisher {
+		case "":
+			errs = append(errs, errors.New("outbox.publisher is required"))
+		case outbox.PublisherStdout:
+		case outbox.PublisherFile:
+			if c.Outbox.Path == "" {
+				errs = append(errs, errors.New("outbox.path is required"))
+			}
+		case outbox.PublisherWebhook:
+			errs = append(errs, c.validateWebhook()...)
+		default:
+			errs = append(errs, fmt.Errorf("outbox.publisher: unknown publisher %q", c.Outbox.Publisher))
+		}
+
+		if c.Outbox.Interval <= 0 {
+			errs = append(errs, errors.New("outbox.interval has to be positive"))
+		}
+
+		if c.Outbox.BatchSize <= 0 {
+			errs = append(errs, errors.New("outbox.batchSize has to be positive"))
+		}
+
+		// the lease has to outlast publishing a batch, which starts at every interval
+		if c.Outbox.LeaseDuration <= c.Outbox.Interval {
+			errs = append(errs, errors.New("outbox.leaseDuration has to be longer than outbox.interval"))
+		}
+	}
+
+	if c.PageSize <= 0 {
+		errs = append(errs, errors.New("pageSize has to be positive"))
+	}
+
+	if c.MaxPageSize < c.PageSize {
+		errs = append(errs, errors.New("maxPageSize cannot be lower than pageSize"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Config) validateWebhook() []error {
+	var errs []error
+
+	endpoints := map[string]string{
+		"likeCreated":    c.Outbox.Webhook.Endpoints.LikeCreated,
+		"matchCreated":   c.Outbox.Webhook.Endpoints.MatchCreated,
+		"matchDissolved": c.Outbox.Webhook.Endpoints.MatchDissolved,
+	}
+
+	var maxEndpoints int
+
+	for eventType, typeEndpoints := range endpoints {
+		parsed := outbox.ParseEndpoints(typeEndpoints)
+		maxEndpoints = max(maxEndpoints, len(parsed))
+
+		for _, endpoint := range parsed {
+			if u, err := url.Parse(endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				errs = append(errs, fmt.Errorf("outbox.webhook.endpoints.%s: invalid URL %q", eventType, endpoint))
+			}
+		}
+	}
+
+	if c.Outbox.Webhook.Secret == "" {
+		errs = append(errs, errors.New("outbox.webhook.secret is required"))
+	}
+
+	if c.Outbox.Webhook.Timeout <= 0 {
+		errs = append(errs, errors.New("outbox.webhook.timeout has to be positive"))
+	}
+
+	if c.Outbox.Webhook.MaxAttempts <= 0 {
+		errs = append(errs, errors.New("outbox.webhook.maxAttempts has to be positive"))
+	}
+
+	if c.Outbox.Webhook.InitialBackoff <= 0 || c.Outbox.Webhook.MaxBackoff < c.Outbox.Webhook.InitialBackoff {
+		errs = append(errs, errors.New(
+			"outbox.webhook.initialBackoff has to be positive and outbox.webhook.maxBackoff cannot be lower",
+		))
+	}
+
+	// the relay extends the lease once half of it is gone, only between the events, and every event is delivered to
+	// each of the endpoints of its type in turn, with a single attempt
+	longestDelivery := time.Duration(max(maxEndpoints, 1)) * c.Outbox.Webhook.Timeout
+
+	if 2*longestDelivery >= c.Outbox.LeaseDuration {
+		errs = append(errs, errors.New(
+			"outbox.leaseDuration has to be more than twice as long as the timeout of the webhook times the largest "+
+				"number of the endpoints of an event type",
+		))
+	}
+
+	return errs
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Watcher reloads the configuration on SIGHUP and whenever its file changes. Only the settings tagged with reload
+// are applied, changes of the other ones are logged and ignored until the service is restarted.
+type Watcher struct {
+	logger  *slog.Logger
+	current *Config
+	load    func() (*Config, error)
+	apply   func(cfg *Config)
+	modTime time.Time
+}
+
+// NewWatcher creates the watcher of the current configuration, which is reloaded using load. The apply function is
+// called with the updated configuration whenever any of the reloadable settings changes.
+func NewWatcher(logger *slog.Logger, current *Config, load func() (*Config, error), apply func(cfg *Config)) *Watcher {
+	return &Watcher{
+		logger:  logger,
+		current: current,
+		load:    load,
+		apply:   apply,
+		modTime: modTime(current.Path),
+	}
+}
+
+// Run watches the configuration until the context is done.
+func (w *Watcher) Run(ctx context.Context) {
+	hangup := make(chan os.Signal, 1)
+
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	var tick <-chan time.Time
+
+	if w.current.ReloadInterval > 0 && w.current.Path != "" {
+		ticker := time.NewTicker(w.current.ReloadInterval)
+		defer ticker.Stop()
+
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hangup:
+			w.logger.Info("reloading configuration on SIGHUP")
+			w.Reload()
+		case <-tick:
+			if t := modTime(w.current.Path); !t.Equal(w.modTime) {
+				w.modTime = t
+
+				w.logger.Info("reloading changed configuration file", slog.String("path", w.current.Path))
+				w.Reload()
+			}
+		}
+	}
+}
+
+// Reload loads the configuration and applies its reloadable settings. The current configuration is kept if the
+// loaded one is invalid. It must not be called concurrently with Run.
+func (w *Watcher) Reload() {
+	next, err := w.load()
+	if err != nil {
+		w.logger.Warn("failed reloading configuration, keeping the current one", slog.Any("error", err))
+
+		return
+	}
+
+	updated := *w.current
+
+	var applied, rejected []string
+
+	nextSettings := settingsOf(next)
+
+	for i, s := range settingsOf(&updated) {
+		if s.value.Equal(nextSettings[i].value) {
+			continue
+		}
+
+		if !s.reload {
+			rejected = append(rejected, s.key)
+
+			continue
+		}
+
+		s.value.Set(nextSettings[i].value)
+		applied = append(applied, s.key)
+	}
+
+	if len(rejected) > 0 {
+		w.logger.Warn(
+			"ignoring changed settings that require restarting the service",
+			slog.String("settings", strings.Join(rejected, ",")),
+		)
+	}
+
+	if len(applied) == 0 {
+		return
+	}
+
+	w.current = &updated
+	w.apply(w.current)
+
+	w.logger.Info(
+		"configuration reloaded",
+		slog.String("settings", strings.Join(applied, ",")),
+		slog.Any("config", w.current),
+	)
+}
+
+func modTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return info.ModTime()
+}
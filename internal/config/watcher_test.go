@@ -0,0 +1,119 @@
+package config_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PatrykPasterny/dating-engine/internal/config"
+)
+
+type watcherFixture struct {
+	path    string
+	applied chan *config.Config
+	watcher *config.Watcher
+}
+
+func newWatcherFixture(t *testing.T, content string) *watcherFixture {
+	t.Helper()
+
+	f := &watcherFixture{
+		path:    writeConfig(t, content),
+		applied: make(chan *config.Config, 1),
+	}
+
+	load := func() (*config.Config, error) {
+		return config.Load([]string{"-config", f.path}, env(nil))
+	}
+
+	current, err := load()
+	require.NoError(t, err)
+
+	f.watcher = config.NewWatcher(slog.New(slog.NewJSONHandler(io.Discard, nil)), current, load, func(cfg *config.Config) {
+		f.applied <- cfg
+	})
+
+	return f
+}
+
+func (f *watcherFixture) write(t *testing.T, content string) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(f.path, []byte(content), 0o600))
+}
+
+func TestWatcherAppliesReloadableSettings(t *testing.T) {
+	f := newWatcherFixture(t, "pageSize: 10\nlogLevel: info\n")
+
+	f.write(t, "pageSize: 15\nlogLevel: debug\n")
+	f.watcher.Reload()
+
+	cfg := <-f.applied
+	require.Equal(t, int64(15), cfg.PageSize)
+	require.Equal(t, slog.LevelDebug, cfg.Level())
+}
+
+func TestWatcherIgnoresSettingsRequiringRestart(t *testing.T) {
+	f := newWatcherFixture(t, "pageSize: 10\nserver:\n  port: 8080\n")
+
+	f.write(t, "pageSize: 15\nserver:\n  port: 9090\n")
+	f.watcher.Reload()
+
+	cfg := <-f.applied
+	require.Equal(t, int64(15), cfg.PageSize)
+	require.Equal(t, "8080", cfg.Server.Port)
+
+	f.write(t, "pageSize: 15\nserver:\n  port: 9191\n")
+	f.watcher.Reload()
+
+	require.Empty(t, f.applied)
+}
+
+func TestWatcherKeepsConfigurationWhenReloadedOneIsInvalid(t *testing.T) {
+	f := newWatcherFixture(t, "pageSize: 10\n")
+
+	f.write(t, "pageSize: -1\n")
+	f.watcher.Reload()
+
+	require.Empty(t, f.applied)
+
+	f.write(t, "pageSize: 10\nmaxPageSize: 50\n")
+	f.watcher.Reload()
+
+	cfg := <-f.applied
+	require.Equal(t, int64(10), cfg.PageSize)
+	require.Equal(t, int64(50), cfg.MaxPageSize)
+}
+
+func TestWatcherReloadsChangedFile(t *testing.T) {
+	f := newWatcherFixture(t, "pageSize: 10\nreloadInterval: 10ms\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		f.watcher.Run(ctx)
+		close(done)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	f.write(t, "pageSize: 25\nreloadInterval: 10ms\n")
+	// the modification time may not change when the file is rewritten within its resolution
+	require.NoError(t, os.Chtimes(f.path, time.Now(), time.Now().Add(time.Minute)))
+
+	select {
+	case cfg := <-f.applied:
+		require.Equal(t, int64(25), cfg.PageSize)
+	case <-time.After(5 * time.Second):
+		t.Fatal("changed configuration file was not reloaded")
+	}
+}
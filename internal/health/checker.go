@@ -0,0 +1,91 @@
+// Package health keeps the gRPC health status of the services in line with the health of their dependencies.
+package health
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// CheckFunc checks whether the dependency is healthy.
+type CheckFunc func(ctx context.Context) error
+
+// Checker periodically runs the check and reports its result as the serving status of the services. The services
+// are reported as not serving until the first check succeeds.
+type Checker struct {
+	logger       *slog.Logger
+	healthServer *health.Server
+	check        CheckFunc
+	interval     time.Duration
+	timeout      time.Duration
+	services     []string
+}
+
+func NewChecker(
+	logger *slog.Logger,
+	healthServer *health.Server,
+	check CheckFunc,
+	interval, timeout time.Duration,
+	services ...string,
+) *Checker {
+	return &Checker{
+		logger:       logger,
+		healthServer: healthServer,
+		check:        check,
+		interval:     interval,
+		timeout:      timeout,
+		services:     append([]string{""}, services...),
+	}
+}
+
+// Run checks the dependency until the context is done.
+func (c *Checker) Run(ctx context.Context) {
+	c.setServingStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	serving := false
+
+	for {
+		err := c.runCheck(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		switch {
+		case err != nil && serving:
+			c.logger.Warn("health check failed, reporting not serving", slog.Any("error", err))
+			c.setServingStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+		case err != nil:
+			c.logger.Debug("health check failed", slog.Any("error", err))
+		case !serving:
+			c.logger.Info("health check succeeded, reporting serving")
+			c.setServingStatus(healthpb.HealthCheckResponse_SERVING)
+		}
+
+		serving = err == nil
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Checker) runCheck(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	return c.check(ctx)
+}
+
+func (c *Checker) setServingStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	for _, service := range c.services {
+		c.healthServer.SetServingStatus(service, status)
+	}
+}
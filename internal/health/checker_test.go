@@ -0,0 +1,95 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	healthcheck "github.com/PatrykPasterny/dating-engine/internal/health"
+)
+
+const service = "explore.ExploreService"
+
+func TestCheckerFollowsCheckResult(t *testing.T) {
+	var healthy atomic.Bool
+
+	healthServer := health.NewServer()
+	checker := healthcheck.NewChecker(
+		slog.New(slog.NewJSONHandler(io.Discard, nil)),
+		healthServer,
+		func(context.Context) error {
+			if healthy.Load() {
+				return nil
+			}
+
+			return errors.New("database is unreachable")
+		},
+		time.Millisecond,
+		time.Second,
+		service,
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		checker.Run(ctx)
+		close(done)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	requireStatus(t, healthServer, healthpb.HealthCheckResponse_NOT_SERVING)
+
+	healthy.Store(true)
+	requireStatus(t, healthServer, healthpb.HealthCheckResponse_SERVING)
+
+	healthy.Store(false)
+	requireStatus(t, healthServer, healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+func TestCheckerTimesOutCheck(t *testing.T) {
+	healthServer := health.NewServer()
+	checker := healthcheck.NewChecker(
+		slog.New(slog.NewJSONHandler(io.Discard, nil)),
+		healthServer,
+		func(ctx context.Context) error {
+			<-ctx.Done()
+
+			return ctx.Err()
+		},
+		time.Millisecond,
+		time.Millisecond,
+		service,
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	checker.Run(ctx)
+
+	requireStatus(t, healthServer, healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+func requireStatus(t *testing.T, healthServer *health.Server, expected healthpb.HealthCheckResponse_ServingStatus) {
+	t.Helper()
+
+	for _, name := range []string{"", service} {
+		require.Eventually(t, func() bool {
+			response, err := healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{Service: name})
+
+			return err == nil && response.GetStatus() == expected
+		}, time.Second, time.Millisecond, "service %q", name)
+	}
+}
@@ -0,0 +1,154 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// RequestIDHeader is the metadata key of the request ID, which is taken from the request if the caller set it
+	// and returned in the response header.
+	RequestIDHeader = "x-request-id"
+
+	maxRequestIDLength = 128
+)
+
+// Interceptor attaches the logger describing the request to its context and logs every handled request. The failed
+// requests are always logged, while only a sample of the successful ones is.
+type Interceptor struct {
+	logger             *slog.Logger
+	successSampleRatio atomic.Uint64
+}
+
+func NewInterceptor(logger *slog.Logger, successSampleRatio float64) *Interceptor {
+	i := &Interceptor{
+		logger: logger,
+	}
+
+	i.SetSuccessSampleRatio(successSampleRatio)
+
+	return i
+}
+
+// SetSuccessSampleRatio changes the fraction of the successful requests that are logged.
+func (i *Interceptor) SetSuccessSampleRatio(ratio float64) {
+	i.successSampleRatio.Store(math.Float64bits(ratio))
+}
+
+func (i *Interceptor) UnaryServerInterceptor(
+	ctx context.Context,
+	request any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	start := time.Now()
+	logger := i.requestLogger(ctx, info.FullMethod)
+
+	response, err := handler(NewContext(ctx, logger), request)
+
+	i.logHandled(ctx, logger, start, err)
+
+	return response, err
+}
+
+// StreamServerInterceptor logs the streams like UnaryServerInterceptor logs the requests, once the stream ends.
+func (i *Interceptor) StreamServerInterceptor(
+	server any,
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	start := time.Now()
+	ctx := stream.Context()
+	logger := i.requestLogger(ctx, info.FullMethod)
+
+	err := handler(server, &scopedStream{ServerStream: stream, ctx: NewContext(ctx, logger)})
+
+	i.logHandled(ctx, logger, start, err)
+
+	return err
+}
+
+// requestLogger returns the logger describing the request, returning its ID in the response header.
+func (i *Interceptor) requestLogger(ctx context.Context, method string) *slog.Logger {
+	requestID := requestIDFromContext(ctx)
+
+	if err := grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, requestID)); err != nil {
+		i.logger.WarnContext(ctx, "failed setting request ID header", slog.Any("error", err))
+	}
+
+	attrs := []any{
+		slog.String("request_id", requestID),
+		slog.String("method", method),
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		attrs = append(attrs, slog.String("peer", p.Addr.String()))
+	}
+
+	return i.logger.With(attrs...)
+}
+
+func (i *Interceptor) logHandled(ctx context.Context, logger *slog.Logger, start time.Time, err error) {
+	code := status.Code(err)
+	level := levelOf(code)
+
+	if level == slog.LevelInfo && rand.Float64() >= math.Float64frombits(i.successSampleRatio.Load()) {
+		return
+	}
+
+	logAttrs := []slog.Attr{
+		slog.Duration("duration", time.Since(start)),
+		slog.String("code", code.String()),
+	}
+
+	if err != nil {
+		logAttrs = append(logAttrs, slog.Any("error", err))
+	}
+
+	logger.LogAttrs(ctx, level, "request handled", logAttrs...)
+}
+
+// scopedStream is the stream whose context carries the logger of the request.
+type scopedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *scopedStream) Context() context.Context {
+	return s.ctx
+}
+
+// levelOf logs the errors caused by the caller as warnings and the ones caused by the service as errors.
+func levelOf(code codes.Code) slog.Level {
+	switch code {
+	case codes.OK:
+		return slog.LevelInfo
+	case codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.PermissionDenied,
+		codes.ResourceExhausted, codes.FailedPrecondition, codes.Aborted, codes.OutOfRange, codes.Unauthenticated:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
+
+func requestIDFromContext(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if values := md.Get(RequestIDHeader); len(values) > 0 && values[0] != "" && len(values[0]) <= maxRequestIDLength {
+		return values[0]
+	}
+
+	return uuid.NewString()
+}
@@ -0,0 +1,55 @@
+// Package logging scopes the logger to the handled request and keeps the user IDs out of the logs if required.
+package logging
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"sync/atomic"
+)
+
+type contextKey struct{}
+
+// NewContext returns the context carrying the logger.
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the logger carried by the context.
+func FromContext(ctx context.Context) (*slog.Logger, bool) {
+	logger, ok := ctx.Value(contextKey{}).(*slog.Logger)
+
+	return logger, ok
+}
+
+// userID is a distinct type, so the user IDs can be recognized among the logged attributes.
+type userID string
+
+// UserID returns the attribute of the user ID, which is hashed by UserIDHashing.
+func UserID(key, id string) slog.Attr {
+	return slog.Any(key, userID(id))
+}
+
+// UserIDHashing replaces the user IDs with their hashes while it is enabled, its ReplaceAttr is meant to be used
+// as slog.HandlerOptions.ReplaceAttr. The hashes are still the same for the same user, so the requests of a user
+// can be correlated.
+type UserIDHashing struct {
+	enabled atomic.Bool
+}
+
+func (h *UserIDHashing) SetEnabled(enabled bool) {
+	h.enabled.Store(enabled)
+}
+
+func (h *UserIDHashing) ReplaceAttr(_ []string, attr slog.Attr) slog.Attr {
+	id, ok := attr.Value.Any().(userID)
+	if !ok || !h.enabled.Load() {
+		return attr
+	}
+
+	hash := sha256.Sum256([]byte(id))
+	attr.Value = slog.StringValue(hex.EncodeToString(hash[:8]))
+
+	return attr
+}
@@ -0,0 +1,182 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/PatrykPasterny/dating-engine/internal/logging"
+)
+
+const method = "/explore.ExploreService/CountLikedYou"
+
+// transportStream records the headers set by the interceptor.
+type transportStream struct {
+	header metadata.MD
+}
+
+func (ts *transportStream) Method() string { return method }
+
+func (ts *transportStream) SetHeader(md metadata.MD) error {
+	ts.header = metadata.Join(ts.header, md)
+
+	return nil
+}
+
+func (ts *transportStream) SendHeader(md metadata.MD) error { return ts.SetHeader(md) }
+
+func (ts *transportStream) SetTrailer(metadata.MD) error { return nil }
+
+type logs struct {
+	bytes.Buffer
+}
+
+func (l *logs) records(t *testing.T) []map[string]any {
+	t.Helper()
+
+	var records []map[string]any
+
+	decoder := json.NewDecoder(&l.Buffer)
+
+	for decoder.More() {
+		var record map[string]any
+
+		require.NoError(t, decoder.Decode(&record))
+
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func intercept(
+	t *testing.T,
+	interceptor *logging.Interceptor,
+	md metadata.MD,
+	handler grpc.UnaryHandler,
+) (*transportStream, error) {
+	t.Helper()
+
+	stream := &transportStream{}
+
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+	ctx = metadata.NewIncomingContext(ctx, md)
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5000}})
+
+	_, err := interceptor.UnaryServerInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+
+	return stream, err
+}
+
+func TestInterceptorScopesLoggerToRequest(t *testing.T) {
+	var buf logs
+
+	interceptor := logging.NewInterceptor(slog.New(slog.NewJSONHandler(&buf, nil)), 1)
+
+	stream, err := intercept(t, interceptor, metadata.Pairs(logging.RequestIDHeader, "request-1"),
+		func(ctx context.Context, _ any) (any, error) {
+			logger, ok := logging.FromContext(ctx)
+			require.True(t, ok)
+
+			logger.InfoContext(ctx, "handling")
+
+			return nil, nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, []string{"request-1"}, stream.header.Get(logging.RequestIDHeader))
+
+	records := buf.records(t)
+	require.Len(t, records, 2)
+
+	for _, record := range records {
+		require.Equal(t, "request-1", record["request_id"])
+		require.Equal(t, method, record["method"])
+		require.Equal(t, "10.0.0.1:5000", record["peer"])
+	}
+
+	require.Equal(t, "request handled", records[1]["msg"])
+	require.Equal(t, "OK", records[1]["code"])
+	require.Contains(t, records[1], "duration")
+}
+
+func TestInterceptorGeneratesRequestID(t *testing.T) {
+	var buf logs
+
+	interceptor := logging.NewInterceptor(slog.New(slog.NewJSONHandler(&buf, nil)), 1)
+
+	stream, err := intercept(t, interceptor, nil, func(context.Context, any) (any, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	requestID := stream.header.Get(logging.RequestIDHeader)
+	require.Len(t, requestID, 1)
+	require.NoError(t, uuid.Validate(requestID[0]))
+	require.Equal(t, requestID[0], buf.records(t)[0]["request_id"])
+}
+
+func TestInterceptorSamplesOnlySuccessfulRequests(t *testing.T) {
+	var buf logs
+
+	interceptor := logging.NewInterceptor(slog.New(slog.NewJSONHandler(&buf, nil)), 0)
+
+	for _, handlerErr := range []error{
+		nil,
+		status.Error(codes.InvalidArgument, "invalid"),
+		status.Error(codes.Internal, "internal error"),
+	} {
+		_, err := intercept(t, interceptor, nil, func(context.Context, any) (any, error) {
+			return nil, handlerErr
+		})
+		require.ErrorIs(t, err, handlerErr)
+	}
+
+	records := buf.records(t)
+	require.Len(t, records, 2)
+	require.Equal(t, "WARN", records[0]["level"])
+	require.Equal(t, "InvalidArgument", records[0]["code"])
+	require.Equal(t, "ERROR", records[1]["level"])
+	require.Equal(t, "Internal", records[1]["code"])
+
+	interceptor.SetSuccessSampleRatio(1)
+
+	_, err := intercept(t, interceptor, nil, func(context.Context, any) (any, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+	require.Len(t, buf.records(t), 1)
+}
+
+func TestUserIDHashing(t *testing.T) {
+	var (
+		buf     logs
+		hashing logging.UserIDHashing
+	)
+
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: hashing.ReplaceAttr}))
+	userID := uuid.NewString()
+
+	logger.Info("plain", logging.UserID("user_id", userID))
+
+	hashing.SetEnabled(true)
+
+	logger.Info("hashed", logging.UserID("user_id", userID))
+	logger.With(logging.UserID("user_id", userID)).Info("hashed again")
+
+	records := buf.records(t)
+	require.Len(t, records, 3)
+	require.Equal(t, userID, records[0]["user_id"])
+	require.NotEqual(t, userID, records[1]["user_id"])
+	require.Equal(t, records[1]["user_id"], records[2]["user_id"])
+}
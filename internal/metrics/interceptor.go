@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor counts the handled requests and observes how long handling them took.
+func (m *Metrics) UnaryServerInterceptor(
+	ctx context.Context,
+	request any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	start := time.Now()
+
+	response, err := handler(ctx, request)
+
+	code := status.Code(err).String()
+
+	m.requests.WithLabelValues(info.FullMethod, code).Inc()
+	m.requestDuration.WithLabelValues(info.FullMethod, code).Observe(time.Since(start).Seconds())
+
+	return response, err
+}
+
+// StreamServerInterceptor counts the handled streams and observes how long they were open.
+func (m *Metrics) StreamServerInterceptor(
+	server any,
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	start := time.Now()
+
+	err := handler(server, stream)
+
+	code := status.Code(err).String()
+
+	m.requests.WithLabelValues(info.FullMethod, code).Inc()
+	m.requestDuration.WithLabelValues(info.FullMethod, code).Observe(time.Since(start).Seconds())
+
+	return err
+}
@@ -0,0 +1,202 @@
+// Package metrics exposes the Prometheus metrics of the service.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+)
+
+const (
+	namespace       = "explore"
+	shutdownTimeout = 5 * time.Second
+)
+
+// Metrics holds all the metrics of the service, registered in its own registry.
+type Metrics struct {
+	registry           *prometheus.Registry
+	requests           *prometheus.CounterVec
+	requestDuration    *prometheus.HistogramVec
+	repositoryDuration *prometheus.HistogramVec
+	transactionRetries prometheus.Counter
+	transactionAborts  prometheus.Counter
+	decisions          *prometheus.CounterVec
+	matches            prometheus.Counter
+	undoneDecisions    *prometheus.CounterVec
+	unmatches          *prometheus.CounterVec
+	reports            *prometheus.CounterVec
+	outboxPublished    *prometheus.CounterVec
+	outboxFailures     *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "requests_total",
+			Help:      "Number of handled gRPC requests by method and status code.",
+		}, []string{"method", "code"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of handling gRPC requests by method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+		repositoryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "repository",
+			Name:      "call_duration_seconds",
+			Help:      "Duration of the match repository calls by method and whether they succeeded.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "success"}),
+		transactionRetries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "repository",
+			Name:      "transaction_retries_total",
+			Help:      "Number of times a decision transaction was retried after a transient error.",
+		}),
+		transactionAborts: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "repository",
+			Name:      "transaction_aborts_total",
+			Help:      "Number of decision transactions that were given up on.",
+		}),
+		decisions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "decisions_total",
+			Help:      "Number of decisions made by type.",
+		}, []string{"decision"}),
+		matches: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "matches_total",
+			Help:      "Number of matches made, likes repeated on matched users are not counted.",
+		}),
+		undoneDecisions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "undone_decisions_total",
+			Help:      "Number of decisions undone by type.",
+		}, []string{"decision"}),
+		unmatches: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "unmatches_total",
+			Help:      "Number of matches dissolved by reason.",
+		}, []string{"reason"}),
+		reports: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reports_total",
+			Help:      "Number of users reported by reason.",
+		}, []string{"reason"}),
+		outboxPublished: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "outbox",
+			Name:      "published_events_total",
+			Help:      "Number of outbox events published by type.",
+		}, []string{"type"}),
+		outboxFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "outbox",
+			Name:      "failed_events_total",
+			Help:      "Number of attempts of publishing the outbox events that failed by type.",
+		}, []string{"type"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+		m.repositoryDuration,
+		m.transactionRetries,
+		m.transactionAborts,
+		m.decisions,
+		m.matches,
+		m.undoneDecisions,
+		m.unmatches,
+		m.reports,
+		m.outboxPublished,
+		m.outboxFailures,
+	)
+
+	return m
+}
+
+// Registry returns the registry the metrics are registered in.
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// TransactionRetried implements repository.TransactionObserver.
+func (m *Metrics) TransactionRetried() {
+	m.transactionRetries.Inc()
+}
+
+// TransactionAborted implements repository.TransactionObserver.
+func (m *Metrics) TransactionAborted() {
+	m.transactionAborts.Inc()
+}
+
+// OutboxEventPublished implements outbox.Observer.
+func (m *Metrics) OutboxEventPublished(eventType model.OutboxEventType) {
+	m.outboxPublished.WithLabelValues(string(eventType)).Inc()
+}
+
+// OutboxEventFailed implements outbox.Observer.
+func (m *Metrics) OutboxEventFailed(eventType model.OutboxEventType) {
+	m.outboxFailures.WithLabelValues(string(eventType)).Inc()
+}
+
+// Serve exposes the metrics on the /metrics path of the address until the context is done.
+func (m *Metrics) Serve(ctx context.Context, logger *slog.Logger, address string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry}))
+
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", address, err)
+	}
+
+	served := make(chan error, 1)
+
+	go func() {
+		served <- server.Serve(lis)
+	}()
+
+	logger.Info("metrics are exposed", slog.String("address", lis.Addr().String()))
+
+	select {
+	case err = <-served:
+		return fmt.Errorf("serving metrics: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err = server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutting down metrics server: %w", err)
+	}
+
+	if err = <-served; !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serving metrics: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package metrics_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/PatrykPasterny/dating-engine/internal/metrics"
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+	"github.com/PatrykPasterny/dating-engine/internal/repository"
+)
+
+func TestInterceptorCountsRequestsByCode(t *testing.T) {
+	m := metrics.New()
+	info := &grpc.UnaryServerInfo{FullMethod: "/explore.ExploreService/CountLikedYou"}
+
+	for _, err := range []error{nil, nil, status.Error(codes.InvalidArgument, "invalid")} {
+		_, _ = m.UnaryServerInterceptor(context.Background(), nil, info, func(context.Context, any) (any, error) {
+			return nil, err
+		})
+	}
+
+	expected := `
+# HELP explore_grpc_requests_total Number of handled gRPC requests by method and status code.
+# TYPE explore_grpc_requests_total counter
+explore_grpc_requests_total{code="InvalidArgument",method="/explore.ExploreService/CountLikedYou"} 1
+explore_grpc_requests_total{code="OK",method="/explore.ExploreService/CountLikedYou"} 2
+`
+
+	require.NoError(t, testutil.GatherAndCompare(
+		m.Registry(),
+		strings.NewReader(expected),
+		"explore_grpc_requests_total",
+	))
+	require.Equal(t, 2, testutil.CollectAndCount(m.Registry(), "explore_grpc_request_duration_seconds"))
+}
+
+func TestRepositoryCountsDecisions(t *testing.T) {
+	m := metrics.New()
+	repo := metrics.NewRepository(m, repository.NewMemoryRepository())
+	firstID, secondID, thirdID := uuid.NewString(), uuid.NewString(), uuid.NewString()
+
+	for _, decision := range []struct {
+		actorID, recipientID string
+		decision             model.Decision
+	}{
+		{actorID: firstID, recipientID: secondID, decision: model.DecisionLike},
+		{actorID: secondID, recipientID: firstID, decision: model.DecisionSuperLike},
+		{actorID: firstID, recipientID: secondID, decision: model.DecisionLike},
+		{actorID: thirdID, recipientID: firstID, decision: model.DecisionPass},
+	} {
+		_, err := repo.MakeDecision(
+			context.Background(),
+			decision.actorID,
+			decision.recipientID,
+			decision.decision,
+			1,
+		)
+		require.NoError(t, err)
+	}
+
+	_, err := repo.CountLikedUser(context.Background(), firstID)
+	require.NoError(t, err)
+
+	_, err = repo.UndoDecision(context.Background(), thirdID, time.Minute)
+	require.NoError(t, err)
+
+	err = repo.Unmatch(context.Background(), firstID, secondID, model.UnmatchReasonNoConnection)
+	require.NoError(t, err)
+
+	_, err = repo.ReportUser(context.Background(), model.Report{
+		ReporterUserID: thirdID,
+		ReportedUserID: firstID,
+		Reason:         model.ReportReasonHarassment,
+	}, 1)
+	require.NoError(t, err)
+
+	expected := `
+# HELP explore_decisions_total Number of decisions made by type.
+# TYPE explore_decisions_total counter
+explore_decisions_total{decision="like"} 2
+explore_decisions_total{decision="pass"} 1
+explore_decisions_total{decision="super_like"} 1
+# HELP explore_matches_total Number of matches made, likes repeated on matched users are not counted.
+# TYPE explore_matches_total counter
+explore_matches_total 1
+# HELP explore_reports_total Number of users reported by reason.
+# TYPE explore_reports_total counter
+explore_reports_total{reason="harassment"} 1
+# HELP explore_undone_decisions_total Number of decisions undone by type.
+# TYPE explore_undone_decisions_total counter
+explore_undone_decisions_total{decision="pass"} 1
+# HELP explore_unmatches_total Number of matches dissolved by reason.
+# TYPE explore_unmatches_total counter
+explore_unmatches_total{reason="no_connection"} 1
+`
+
+	require.NoError(t, testutil.GatherAndCompare(
+		m.Registry(),
+		strings.NewReader(expected),
+		"explore_decisions_total",
+		"explore_matches_total",
+		"explore_reports_total",
+		"explore_undone_decisions_total",
+		"explore_unmatches_total",
+	))
+	require.Equal(t, 5, testutil.CollectAndCount(m.Registry(), "explore_repository_call_duration_seconds"))
+}
+
+func TestServeExposesMetrics(t *testing.T) {
+	m := metrics.New()
+	m.TransactionRetried()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	address := lis.Addr().String()
+	require.NoError(t, lis.Close())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+
+	go func() {
+		served <- m.Serve(ctx, slog.New(slog.NewJSONHandler(io.Discard, nil)), address)
+	}()
+
+	var body []byte
+
+	require.Eventually(t, func() bool {
+		response, err := http.Get("http://" + address + "/metrics")
+		if err != nil {
+			return false
+		}
+		defer response.Body.Close()
+
+		body, err = io.ReadAll(response.Body)
+
+		return err == nil && response.StatusCode == http.StatusOK
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Contains(t, string(body), "explore_repository_transaction_retries_total 1")
+
+	cancel()
+	require.NoError(t, <-served)
+}
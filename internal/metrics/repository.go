@@ -0,0 +1,219 @@
+package metrics
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+	"github.com/PatrykPasterny/dating-engine/internal/pagination"
+)
+
+// MatchRepository is the repository the calls of which are measured.
+type MatchRepository interface {
+	GetLikedUser(ctx context.Context, userID string, page pagination.Page) ([]model.Match, error)
+	GetNewLikedUser(ctx context.Context, userID string, page pagination.Page) ([]model.Match, error)
+	CountLikedUser(ctx context.Context, userID string) (uint64, error)
+	MakeDecision(
+		ctx context.Context,
+		userID, recipientID string,
+		decision model.Decision,
+		superLikeLimit int64,
+	) (model.DecisionResult, error)
+	UndoDecision(ctx context.Context, userID string, window time.Duration) (model.DecisionRecord, error)
+	Unmatch(ctx context.Context, userID, matchedUserID string, reason model.UnmatchReason) error
+	BlockUser(ctx context.Context, userID, blockedUserID string) error
+	UnblockUser(ctx context.Context, userID, blockedUserID string) error
+	GetBlockedUsers(ctx context.Context, userID string) ([]model.Block, error)
+	ReportUser(ctx context.Context, report model.Report, hideThreshold int64) (model.ReportResult, error)
+	GetReports(ctx context.Context, state model.ReportState, page pagination.ReportPage) ([]model.Report, error)
+	ResolveReport(
+		ctx context.Context,
+		reportID string,
+		state model.ReportState,
+		note string,
+		hideThreshold int64,
+	) (model.ReportResult, error)
+}
+
+// Repository observes the duration of every call of the wrapped repository and counts the decisions made.
+type Repository struct {
+	metrics    *Metrics
+	repository MatchRepository
+}
+
+func NewRepository(metrics *Metrics, repository MatchRepository) *Repository {
+	return &Repository{
+		metrics:    metrics,
+		repository: repository,
+	}
+}
+
+func (r *Repository) GetLikedUser(ctx context.Context, userID string, page pagination.Page) ([]model.Match, error) {
+	start := time.Now()
+
+	matches, err := r.repository.GetLikedUser(ctx, userID, page)
+	r.observe("GetLikedUser", start, err)
+
+	return matches, err
+}
+
+func (r *Repository) GetNewLikedUser(ctx context.Context, userID string, page pagination.Page) ([]model.Match, error) {
+	start := time.Now()
+
+	matches, err := r.repository.GetNewLikedUser(ctx, userID, page)
+	r.observe("GetNewLikedUser", start, err)
+
+	return matches, err
+}
+
+func (r *Repository) CountLikedUser(ctx context.Context, userID string) (uint64, error) {
+	start := time.Now()
+
+	count, err := r.repository.CountLikedUser(ctx, userID)
+	r.observe("CountLikedUser", start, err)
+
+	return count, err
+}
+
+func (r *Repository) MakeDecision(
+	ctx context.Context,
+	userID, recipientID string,
+	decision model.Decision,
+	superLikeLimit int64,
+) (model.DecisionResult, error) {
+	start := time.Now()
+
+	result, err := r.repository.MakeDecision(ctx, userID, recipientID, decision, superLikeLimit)
+	r.observe("MakeDecision", start, err)
+
+	if err != nil {
+		return result, err
+	}
+
+	r.metrics.decisions.WithLabelValues(decision.String()).Inc()
+
+	if result.MatchCreated {
+		r.metrics.matches.Inc()
+	}
+
+	return result, nil
+}
+
+func (r *Repository) UndoDecision(
+	ctx context.Context,
+	userID string,
+	window time.Duration,
+) (model.DecisionRecord, error) {
+	start := time.Now()
+
+	record, err := r.repository.UndoDecision(ctx, userID, window)
+	r.observe("UndoDecision", start, err)
+
+	if err != nil {
+		return record, err
+	}
+
+	r.metrics.undoneDecisions.WithLabelValues(record.Decision.String()).Inc()
+
+	return record, nil
+}
+
+func (r *Repository) Unmatch(
+	ctx context.Context,
+	userID, matchedUserID string,
+	reason model.UnmatchReason,
+) error {
+	start := time.Now()
+
+	err := r.repository.Unmatch(ctx, userID, matchedUserID, reason)
+	r.observe("Unmatch", start, err)
+
+	if err != nil {
+		return err
+	}
+
+	r.metrics.unmatches.WithLabelValues(string(reason)).Inc()
+
+	return nil
+}
+
+func (r *Repository) BlockUser(ctx context.Context, userID, blockedUserID string) error {
+	start := time.Now()
+
+	err := r.repository.BlockUser(ctx, userID, blockedUserID)
+	r.observe("BlockUser", start, err)
+
+	return err
+}
+
+func (r *Repository) UnblockUser(ctx context.Context, userID, blockedUserID string) error {
+	start := time.Now()
+
+	err := r.repository.UnblockUser(ctx, userID, blockedUserID)
+	r.observe("UnblockUser", start, err)
+
+	return err
+}
+
+func (r *Repository) GetBlockedUsers(ctx context.Context, userID string) ([]model.Block, error) {
+	start := time.Now()
+
+	blocks, err := r.repository.GetBlockedUsers(ctx, userID)
+	r.observe("GetBlockedUsers", start, err)
+
+	return blocks, err
+}
+
+func (r *Repository) ReportUser(
+	ctx context.Context,
+	report model.Report,
+	hideThreshold int64,
+) (model.ReportResult, error) {
+	start := time.Now()
+
+	result, err := r.repository.ReportUser(ctx, report, hideThreshold)
+	r.observe("ReportUser", start, err)
+
+	if err != nil {
+		return result, err
+	}
+
+	r.metrics.reports.WithLabelValues(string(report.Reason)).Inc()
+
+	return result, nil
+}
+
+func (r *Repository) GetReports(
+	ctx context.Context,
+	state model.ReportState,
+	page pagination.ReportPage,
+) ([]model.Report, error) {
+	start := time.Now()
+
+	reports, err := r.repository.GetReports(ctx, state, page)
+	r.observe("GetReports", start, err)
+
+	return reports, err
+}
+
+func (r *Repository) ResolveReport(
+	ctx context.Context,
+	reportID string,
+	state model.ReportState,
+	note string,
+	hideThreshold int64,
+) (model.ReportResult, error) {
+	start := time.Now()
+
+	result, err := r.repository.ResolveReport(ctx, reportID, state, note, hideThreshold)
+	r.observe("ResolveReport", start, err)
+
+	return result, err
+}
+
+func (r *Repository) observe(method string, start time.Time, err error) {
+	r.metrics.repositoryDuration.
+		WithLabelValues(method, strconv.FormatBool(err == nil)).
+		Observe(time.Since(start).Seconds())
+}
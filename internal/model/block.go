@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// Block hides the users from each other and keeps them from deciding about each other until it is lifted.
+type Block struct {
+	BlockerUserID string    `json:"blockerUserID" bson:"blockerUserID"`
+	BlockedUserID string    `json:"blockedUserID" bson:"blockedUserID"`
+	CreatedAt     time.Time `json:"createdAt" bson:"createdAt"`
+}
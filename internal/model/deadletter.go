@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// DeadLetter is the delivery of the outbox event to the webhook endpoint that failed for good. It is kept until it
+// is replayed successfully.
+type DeadLetter struct {
+	ID       string      `bson:"_id"`
+	Event    OutboxEvent `bson:"event"`
+	Endpoint string      `bson:"endpoint"`
+	// Attempts counts all the attempts of the delivery, including the ones made by the replays.
+	Attempts  int64     `bson:"attempts"`
+	LastError string    `bson:"lastError"`
+	FailedAt  time.Time `bson:"failedAt"`
+}
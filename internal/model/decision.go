@@ -0,0 +1,63 @@
+package model
+
+import "time"
+
+// Decision is what the actor decided about the recipient. It is stored as a number ordered by the interest shown,
+// so the likers can be sorted by it.
+type Decision int
+
+const (
+	// DecisionNone is the decision of the actor who has not decided yet.
+	DecisionNone Decision = iota
+	DecisionPass
+	DecisionLike
+	// DecisionSuperLike is the like the actor wants the recipient to notice first, the actors have a limited
+	// number of them per day.
+	DecisionSuperLike
+)
+
+// Liked reports whether the decision is any kind of like.
+func (d Decision) Liked() bool {
+	return d == DecisionLike || d == DecisionSuperLike
+}
+
+func (d Decision) String() string {
+	switch d {
+	case DecisionNone:
+		return "none"
+	case DecisionPass:
+		return "pass"
+	case DecisionLike:
+		return "like"
+	case DecisionSuperLike:
+		return "super_like"
+	default:
+		return "unknown"
+	}
+}
+
+// DecisionRecord is an entry of the decision history of the actor, it keeps what the actor had decided about the
+// recipient before, so the decision can be undone.
+type DecisionRecord struct {
+	ID              string   `json:"id" bson:"_id"`
+	ActorUserID     string   `json:"actorUserID" bson:"actorUserID"`
+	RecipientUserID string   `json:"recipientUserID" bson:"recipientUserID"`
+	Decision        Decision `json:"decision" bson:"decision"`
+	// Previous is the match of the actor with the recipient before the decision, it is nil if the actor had not
+	// decided about the recipient before.
+	Previous *Match `json:"previous" bson:"previous,omitempty"`
+	// SuperLikeUsed tells whether the decision used up one of the daily super likes of the actor.
+	SuperLikeUsed bool      `json:"superLikeUsed" bson:"superLikeUsed"`
+	DecidedAt     time.Time `json:"decidedAt" bson:"decidedAt"`
+	// UndoneAt is the time the decision was undone, it is empty if it was not.
+	UndoneAt time.Time `json:"undoneAt" bson:"undoneAt,omitempty"`
+}
+
+// DecisionResult is what the decision changed about the match of the actor and the recipient.
+type DecisionResult struct {
+	// MutualLikes tells whether the actor and the recipient like each other after the decision.
+	MutualLikes bool
+	// MatchCreated tells whether the decision made the match, a like repeated on a matched recipient keeps the
+	// match without making it again.
+	MatchCreated bool
+}
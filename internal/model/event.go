@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// EventKind tells what changed for the user the event is delivered to.
+type EventKind string
+
+const (
+	// EventKindLike is delivered when the other user liked the user without matching.
+	EventKindLike EventKind = "like"
+	// EventKindMatch is delivered when the users liked each other.
+	EventKindMatch EventKind = "match"
+	// EventKindUnmatch is delivered when the match of the users was dissolved.
+	EventKindUnmatch EventKind = "unmatch"
+)
+
+// Event notifies the user about a change of the likes made by a decision, an undo, an unmatch or a block.
+type Event struct {
+	ID   string    `json:"id" bson:"_id"`
+	Kind EventKind `json:"kind" bson:"kind"`
+	// UserID is the user the event is delivered to.
+	UserID string `json:"userID" bson:"userID"`
+	// OtherUserID is the user who liked, matched or was unmatched with the user.
+	OtherUserID string `json:"otherUserID" bson:"otherUserID"`
+	// SuperLiked is set on the like events of the super likes.
+	SuperLiked bool      `json:"superLiked,omitempty" bson:"superLiked,omitempty"`
+	At         time.Time `json:"at" bson:"at"`
+}
@@ -1,12 +1,37 @@
 package model
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+)
 
 type Match struct {
 	RecipientUserID string `json:"recipientUserID" bson:"recipientUserID"`
 	ActorUserID     string `json:"actorUserID" bson:"actorUserID"`
 	Liked           bool   `json:"liked" bson:"liked"`
 	Matched         bool   `json:"matched" bson:"matched"`
+	// Decision is the latest decision of the actor, Liked is kept in line with it. It is empty for the matches
+	// created before the decisions were stored, in which case Liked tells whether it was a like.
+	Decision Decision `json:"decision" bson:"decision,omitempty"`
+	// CreatedAt is the time of the first decision of the actor on the recipient.
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt,omitempty"`
+	// UpdatedAt is the time of the latest decision of the actor on the recipient.
+	UpdatedAt time.Time `json:"updatedAt" bson:"updatedAt,omitempty"`
+	// MatchedAt is the time both users liked each other, it is empty if they are not matched.
+	MatchedAt time.Time `json:"matchedAt" bson:"matchedAt,omitempty"`
+	// Unmatch is set once either user dissolved the match, after which the users never match or see each other in
+	// the liker lists again.
+	Unmatch *Unmatch `json:"unmatch,omitempty" bson:"unmatch,omitempty"`
+}
+
+// Unmatched reports whether the match of the users was dissolved.
+func (m *Match) Unmatched() bool {
+	return m.Unmatch != nil
+}
+
+// SuperLiked reports whether the actor super liked the recipient.
+func (m *Match) SuperLiked() bool {
+	return m.Decision == DecisionSuperLike
 }
 
 func (m *Match) UnmarshalBinary(data []byte) error {
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// OutboxEventType is the type of the domain event published to the other services.
+type OutboxEventType string
+
+const (
+	// OutboxEventLikeCreated is written when the actor likes the recipient they did not like before.
+	OutboxEventLikeCreated OutboxEventType = "LikeCreated"
+	// OutboxEventMatchCreated is written when the like of the actor matches the users.
+	OutboxEventMatchCreated OutboxEventType = "MatchCreated"
+	// OutboxEventMatchDissolved is written when the actor dissolves the match of the users.
+	OutboxEventMatchDissolved OutboxEventType = "MatchDissolved"
+)
+
+const (
+	// DissolvedReasonPassed dissolves the match when the actor passes the matched recipient.
+	DissolvedReasonPassed = "passed"
+	// DissolvedReasonUndone dissolves the match when the actor undoes the like that made it.
+	DissolvedReasonUndone = "undone"
+)
+
+// OutboxEvent is the domain event written in the same transaction as the change it describes, and published to the
+// other services afterwards.
+type OutboxEvent struct {
+	ID   string          `json:"id" bson:"_id"`
+	Type OutboxEventType `json:"type" bson:"type"`
+	// PairKey identifies the pair of the users whichever of them is the actor, see PairKey.
+	PairKey string `json:"pairKey" bson:"pairKey"`
+	// Sequence numbers the events of the pair from one in the order they were written, they are published in this
+	// order.
+	Sequence int64 `json:"sequence" bson:"sequence"`
+	// ActorUserID is the user whose decision, unmatch or block caused the event.
+	ActorUserID     string `json:"actorUserID" bson:"actorUserID"`
+	RecipientUserID string `json:"recipientUserID" bson:"recipientUserID"`
+	// SuperLiked is set on the LikeCreated events of the super likes.
+	SuperLiked bool `json:"superLiked,omitempty" bson:"superLiked,omitempty"`
+	// Reason tells why the match was dissolved: one of the unmatch reasons, passed or undone.
+	Reason     string    `json:"reason,omitempty" bson:"reason,omitempty"`
+	OccurredAt time.Time `json:"occurredAt" bson:"occurredAt"`
+	// PublishedAt is empty until the relay publishes the event.
+	PublishedAt time.Time `json:"-" bson:"publishedAt,omitempty"`
+	// Attempts counts the failed attempts of publishing the event, which is published again at NextAttemptAt at the
+	// earliest.
+	Attempts      int64     `json:"-" bson:"attempts,omitempty"`
+	NextAttemptAt time.Time `json:"-" bson:"nextAttemptAt,omitempty"`
+	// DeliveredTo are the webhook endpoints done with the event while it is published again for the others, as it
+	// was either delivered to them or moved to their dead letters.
+	DeliveredTo []string `json:"-" bson:"deliveredTo,omitempty"`
+}
+
+// PairKey returns the key of the pair of the users, which is the same whichever of them is given first.
+func PairKey(userID, otherUserID string) string {
+	if userID > otherUserID {
+		userID, otherUserID = otherUserID, userID
+	}
+
+	return strings.Join([]string{userID, otherUserID}, ":")
+}
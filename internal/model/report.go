@@ -0,0 +1,52 @@
+package model
+
+import "time"
+
+// ReportReason tells why the user was reported.
+type ReportReason string
+
+const (
+	ReportReasonSpam                 ReportReason = "spam"
+	ReportReasonFakeProfile          ReportReason = "fake_profile"
+	ReportReasonInappropriateContent ReportReason = "inappropriate_content"
+	ReportReasonHarassment           ReportReason = "harassment"
+	ReportReasonUnderage             ReportReason = "underage"
+	ReportReasonOther                ReportReason = "other"
+)
+
+// ReportState is where the report is in the moderation queue.
+type ReportState string
+
+const (
+	// ReportStateOpen is the state of the report waiting for the review.
+	ReportStateOpen ReportState = "open"
+	// ReportStateActioned is the state of the report the moderators acted upon.
+	ReportStateActioned ReportState = "actioned"
+	// ReportStateDismissed is the state of the report the moderators found unfounded.
+	ReportStateDismissed ReportState = "dismissed"
+)
+
+// Report is the complaint of the reporter about the reported user, waiting for or after the review.
+type Report struct {
+	ID             string       `json:"id" bson:"_id"`
+	ReporterUserID string       `json:"reporterUserID" bson:"reporterUserID"`
+	ReportedUserID string       `json:"reportedUserID" bson:"reportedUserID"`
+	Reason         ReportReason `json:"reason" bson:"reason"`
+	// Details is the free text description of the reporter.
+	Details string `json:"details" bson:"details,omitempty"`
+	// EvidenceRefs point to the evidence kept outside the service, e.g. the messages or the photos.
+	EvidenceRefs []string    `json:"evidenceRefs" bson:"evidenceRefs,omitempty"`
+	State        ReportState `json:"state" bson:"state"`
+	CreatedAt    time.Time   `json:"createdAt" bson:"createdAt"`
+	// ResolvedAt is the time the report was reviewed, it is empty while the report is open.
+	ResolvedAt time.Time `json:"resolvedAt" bson:"resolvedAt,omitempty"`
+	// ResolutionNote is the free text note of the moderator who reviewed the report.
+	ResolutionNote string `json:"resolutionNote" bson:"resolutionNote,omitempty"`
+}
+
+// ReportResult is the report made or resolved together with what it changed about the reported user.
+type ReportResult struct {
+	Report Report
+	// HiddenChanged tells whether the reported user got hidden from or shown again in the liker lists of the others.
+	HiddenChanged bool
+}
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// UnmatchReason tells why the user dissolved the match.
+type UnmatchReason string
+
+const (
+	UnmatchReasonNoLongerInterested     UnmatchReason = "no_longer_interested"
+	UnmatchReasonNoConnection           UnmatchReason = "no_connection"
+	UnmatchReasonInappropriateBehaviour UnmatchReason = "inappropriate_behaviour"
+	UnmatchReasonMetSomeone             UnmatchReason = "met_someone"
+	UnmatchReasonOther                  UnmatchReason = "other"
+	// UnmatchReasonBlocked dissolves the match of the users when either of them blocks the other.
+	UnmatchReasonBlocked UnmatchReason = "blocked"
+)
+
+// Unmatch records the dissolution of the match, it is stored on the matches of both users.
+type Unmatch struct {
+	// UserID is the user who dissolved the match.
+	UserID string        `json:"userID" bson:"userID"`
+	Reason UnmatchReason `json:"reason" bson:"reason"`
+	At     time.Time     `json:"at" bson:"at"`
+}
@@ -0,0 +1,49 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+)
+
+const (
+	// PublisherStdout writes the events to the standard output.
+	PublisherStdout = "stdout"
+	// PublisherFile appends the events to the file.
+	PublisherFile = "file"
+)
+
+// Publisher delivers the outbox events to the other services. The relay publishes the events of a pair one by one in
+// their order and publishes the event again when publishing it or marking it published failed, so the same event can
+// be published more than once.
+type Publisher interface {
+	Publish(ctx context.Context, event model.OutboxEvent) error
+}
+
+// WriterPublisher writes every event as a line of JSON to the writer, it is meant for local development.
+type WriterPublisher struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+func NewWriterPublisher(w io.Writer) *WriterPublisher {
+	return &WriterPublisher{
+		encoder: json.NewEncoder(w),
+	}
+}
+
+// Publish implements Publisher.
+func (wp *WriterPublisher) Publish(_ context.Context, event model.OutboxEvent) error {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	if err := wp.encoder.Encode(event); err != nil {
+		return fmt.Errorf("writing outbox event: %w", err)
+	}
+
+	return nil
+}
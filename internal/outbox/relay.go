@@ -0,0 +1,246 @@
+// Package outbox publishes the domain events written to the outbox by the match repository.
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+)
+
+// Store keeps the outbox events until they are published.
+type Store interface {
+	// GetPendingOutboxEvents returns all the events not published yet of the pairs of the given number of the oldest
+	// such events whose next attempt is due, ordered by the pair and their sequence.
+	GetPendingOutboxEvents(ctx context.Context, limit int64) ([]model.OutboxEvent, error)
+	// MarkOutboxEventPublished records that the event was published, it fails once the lease is taken over by another
+	// owner after the given term.
+	MarkOutboxEventPublished(ctx context.Context, eventID string, leaseTerm int64) error
+	// RetryOutboxEvent records the failed attempt of publishing the event, which is published again at the given time
+	// at the earliest. It fails once the lease is taken over by another owner after the given term.
+	RetryOutboxEvent(ctx context.Context, eventID string, leaseTerm int64, nextAttemptAt time.Time) error
+	// AcquireOutboxLease takes or extends the lease of publishing the events and reports whether the owner holds it
+	// along with the term of the lease, which moves on whenever another owner takes the lease over.
+	AcquireOutboxLease(ctx context.Context, owner string, duration time.Duration) (int64, bool, error)
+}
+
+// Observer is notified about the events the relay publishes.
+type Observer interface {
+	// OutboxEventPublished is called once the event is published and marked published.
+	OutboxEventPublished(eventType model.OutboxEventType)
+	// OutboxEventFailed is called when publishing the event or marking it published fails.
+	OutboxEventFailed(eventType model.OutboxEventType)
+}
+
+type noopObserver struct{}
+
+func (noopObserver) OutboxEventPublished(model.OutboxEventType) {}
+
+func (noopObserver) OutboxEventFailed(model.OutboxEventType) {}
+
+// Relay periodically publishes the pending outbox events while it holds the lease, so only one of the instances of the
+// service publishes them at a time. Every event is published once per round: an event that fails is published again
+// in a later round, once its backoff passes, and the later events of its pair wait for it, which keeps the events of
+// every pair in order without holding up the other pairs. The relay that lost the lease, e.g. after stalling for longer
+// than the lease lasts, can no longer mark the events, although the event it was publishing at the time may still
+// be published once more, after the later events of its pair.
+type Relay struct {
+	logger        *slog.Logger
+	store         Store
+	publisher     Publisher
+	observer      Observer
+	owner         string
+	interval      time.Duration
+	leaseDuration time.Duration
+	batchSize     int64
+	// the backoff before publishing the failed event again starts at initialBackoff and doubles up to maxBackoff
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+// RelayOption changes how the relay is created.
+type RelayOption func(r *Relay)
+
+// WithBackoff sets how long the failed events wait before they are published again, the backoff starts at
+// initialBackoff and doubles after every failed attempt up to maxBackoff. The failed events are published again in
+// the next round by default.
+func WithBackoff(initialBackoff, maxBackoff time.Duration) RelayOption {
+	return func(r *Relay) {
+		r.initialBackoff = initialBackoff
+		r.maxBackoff = maxBackoff
+	}
+}
+
+// WithObserver sets the observer of the published events.
+func WithObserver(observer Observer) RelayOption {
+	return func(r *Relay) {
+		r.observer = observer
+	}
+}
+
+func NewRelay(
+	logger *slog.Logger,
+	store Store,
+	publisher Publisher,
+	interval, leaseDuration time.Duration,
+	batchSize int64,
+	opts ...RelayOption,
+) *Relay {
+	r := &Relay{
+		logger:        logger,
+		store:         store,
+		publisher:     publisher,
+		observer:      noopObserver{},
+		owner:         uuid.NewString(),
+		interval:      interval,
+		leaseDuration: leaseDuration,
+		batchSize:     batchSize,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Run publishes the events until the context is done.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.publishPending(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Relay) publishPending(ctx context.Context) {
+	leaseTerm, held := r.holdLease(ctx)
+	if !held {
+		return
+	}
+
+	leasedAt := time.Now()
+
+	events, err := r.store.GetPendingOutboxEvents(ctx, r.batchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			r.logger.Warn("failed getting pending outbox events", slog.Any("error", err))
+		}
+
+		return
+	}
+
+	failedPairs := make(map[string]bool)
+	now := time.Now()
+
+	for _, event := range events {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if failedPairs[event.PairKey] {
+			continue
+		}
+
+		// the event that failed recently holds up the later events of its pair until its backoff passes
+		if event.NextAttemptAt.After(now) {
+			failedPairs[event.PairKey] = true
+
+			continue
+		}
+
+		// the slow publishers may outlast the lease, so it is extended before it runs out
+		if time.Since(leasedAt) > r.leaseDuration/2 {
+			if leaseTerm, held = r.holdLease(ctx); !held {
+				return
+			}
+
+			leasedAt = time.Now()
+		}
+
+		if err = r.publish(ctx, event, leaseTerm); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			// the lease taken over by another relay fails marking the events, which the other relay publishes now
+			if leaseTerm, held = r.holdLease(ctx); !held {
+				return
+			}
+
+			leasedAt = time.Now()
+
+			r.logger.Warn(
+				"failed publishing outbox event, publishing the events of the pair again later",
+				slog.String("event_id", event.ID),
+				slog.String("pair_key", event.PairKey),
+				slog.Int64("attempts", event.Attempts+1),
+				slog.Any("error", err),
+			)
+
+			r.observer.OutboxEventFailed(event.Type)
+			failedPairs[event.PairKey] = true
+
+			r.retryLater(ctx, event, leaseTerm)
+
+			continue
+		}
+
+		r.observer.OutboxEventPublished(event.Type)
+	}
+}
+
+// holdLease takes or extends the lease and reports whether the relay holds it along with the term of the lease.
+func (r *Relay) holdLease(ctx context.Context) (int64, bool) {
+	leaseTerm, held, err := r.store.AcquireOutboxLease(ctx, r.owner, r.leaseDuration)
+	if err != nil {
+		if ctx.Err() == nil {
+			r.logger.Warn("failed acquiring outbox lease", slog.Any("error", err))
+		}
+
+		return 0, false
+	}
+
+	if !held {
+		r.logger.Debug("outbox lease is held by another relay")
+	}
+
+	return leaseTerm, held
+}
+
+// retryLater records the failed attempt of publishing the event, so the publisher knows how many attempts were made
+// and the event waits for its backoff. The event is published again in the next round if recording fails.
+func (r *Relay) retryLater(ctx context.Context, event model.OutboxEvent, leaseTerm int64) {
+	backoff := r.initialBackoff
+
+	for attempt := int64(1); attempt <= event.Attempts && backoff < r.maxBackoff; attempt++ {
+		backoff = min(2*backoff, r.maxBackoff)
+	}
+
+	err := r.store.RetryOutboxEvent(ctx, event.ID, leaseTerm, time.Now().Add(backoff))
+	if err != nil && ctx.Err() == nil {
+		r.logger.Warn(
+			"failed recording outbox event attempt",
+			slog.String("event_id", event.ID),
+			slog.Any("error", err),
+		)
+	}
+}
+
+func (r *Relay) publish(ctx context.Context, event model.OutboxEvent, leaseTerm int64) error {
+	if err := r.publisher.Publish(ctx, event); err != nil {
+		return err
+	}
+
+	return r.store.MarkOutboxEventPublished(ctx, event.ID, leaseTerm)
+}
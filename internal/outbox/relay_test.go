@@ -0,0 +1,361 @@
+package outbox_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+	"github.com/PatrykPasterny/dating-engine/internal/outbox"
+	"github.com/PatrykPasterny/dating-engine/internal/repository"
+)
+
+// flakyPublisher fails publishing every event the first time it is published.
+type flakyPublisher struct {
+	mu        sync.Mutex
+	failed    map[string]bool
+	published []model.OutboxEvent
+}
+
+func newFlakyPublisher() *flakyPublisher {
+	return &flakyPublisher{
+		failed: make(map[string]bool),
+	}
+}
+
+func (fp *flakyPublisher) Publish(_ context.Context, event model.OutboxEvent) error {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	if !fp.failed[event.ID] {
+		fp.failed[event.ID] = true
+
+		return errors.New("broker is unreachable")
+	}
+
+	fp.published = append(fp.published, event)
+
+	return nil
+}
+
+func (fp *flakyPublisher) publishedEvents() []model.OutboxEvent {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	return slices.Clone(fp.published)
+}
+
+// brokenPublisher fails publishing every event of the given actors.
+type brokenPublisher struct {
+	mu           sync.Mutex
+	brokenActors []string
+	published    []model.OutboxEvent
+}
+
+func (bp *brokenPublisher) Publish(_ context.Context, event model.OutboxEvent) error {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	if slices.Contains(bp.brokenActors, event.ActorUserID) {
+		return errors.New("sink is broken")
+	}
+
+	bp.published = append(bp.published, event)
+
+	return nil
+}
+
+func (bp *brokenPublisher) publishedEvents() []model.OutboxEvent {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	return slices.Clone(bp.published)
+}
+
+func TestRelayPublishesEventsOfPairInOrder(t *testing.T) {
+	ctx := context.Background()
+	memoryRepository := repository.NewMemoryRepository()
+
+	decide(t, memoryRepository, "alice", "bob", model.DecisionLike)
+	decide(t, memoryRepository, "bob", "alice", model.DecisionSuperLike)
+	decide(t, memoryRepository, "alice", "bob", model.DecisionPass)
+	decide(t, memoryRepository, "carol", "bob", model.DecisionLike)
+
+	publisher := newFlakyPublisher()
+	relay := outbox.NewRelay(
+		slog.New(slog.NewJSONHandler(io.Discard, nil)),
+		memoryRepository,
+		publisher,
+		time.Millisecond,
+		time.Minute,
+		1,
+	)
+
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		relay.Run(runCtx)
+		close(done)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	require.Eventually(t, func() bool {
+		events, err := memoryRepository.GetPendingOutboxEvents(ctx, math.MaxInt64)
+
+		return err == nil && len(events) == 0
+	}, time.Second, time.Millisecond)
+
+	var pairEvents []model.OutboxEvent
+
+	for _, event := range publisher.publishedEvents() {
+		if event.PairKey == model.PairKey("alice", "bob") {
+			pairEvents = append(pairEvents, event)
+		}
+	}
+
+	require.Len(t, publisher.publishedEvents(), 5)
+	require.Len(t, pairEvents, 4)
+
+	expectedTypes := []model.OutboxEventType{
+		model.OutboxEventLikeCreated,
+		model.OutboxEventLikeCreated,
+		model.OutboxEventMatchCreated,
+		model.OutboxEventMatchDissolved,
+	}
+
+	for i, event := range pairEvents {
+		require.Equal(t, int64(i+1), event.Sequence)
+		require.Equal(t, expectedTypes[i], event.Type)
+	}
+
+	require.True(t, pairEvents[1].SuperLiked)
+	require.Equal(t, model.DissolvedReasonPassed, pairEvents[3].Reason)
+}
+
+func TestRelayWaitsForBackoffOfFailedEvents(t *testing.T) {
+	ctx := context.Background()
+	memoryRepository := repository.NewMemoryRepository()
+
+	decide(t, memoryRepository, "alice", "bob", model.DecisionLike)
+	decide(t, memoryRepository, "bob", "alice", model.DecisionLike)
+	decide(t, memoryRepository, "carol", "bob", model.DecisionLike)
+
+	publisher := newFlakyPublisher()
+	relay := outbox.NewRelay(
+		slog.New(slog.NewJSONHandler(io.Discard, nil)),
+		memoryRepository,
+		publisher,
+		time.Millisecond,
+		time.Minute,
+		10,
+		outbox.WithBackoff(time.Hour, time.Hour),
+	)
+
+	runCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+
+	relay.Run(runCtx)
+
+	require.Empty(t, publisher.publishedEvents())
+
+	// the event of carol and bob waits for its backoff, the later events of alice and bob are due but wait for the
+	// first one
+	events, err := memoryRepository.GetPendingOutboxEvents(ctx, math.MaxInt64)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	// only the first event of every pair was attempted, the later ones wait for it
+	for _, event := range events {
+		if event.Sequence > 1 {
+			require.Zero(t, event.Attempts)
+
+			continue
+		}
+
+		require.Equal(t, int64(1), event.Attempts)
+		require.True(t, event.NextAttemptAt.After(time.Now().Add(time.Minute)))
+	}
+}
+
+func TestRelayPublishesNewerPairsWhileMorePairsBackOffThanBatchHolds(t *testing.T) {
+	ctx := context.Background()
+	memoryRepository := repository.NewMemoryRepository()
+
+	decide(t, memoryRepository, "alice", "bob", model.DecisionLike)
+	decide(t, memoryRepository, "carol", "bob", model.DecisionLike)
+	decide(t, memoryRepository, "dave", "bob", model.DecisionLike)
+	decide(t, memoryRepository, "erin", "bob", model.DecisionLike)
+
+	publisher := &brokenPublisher{brokenActors: []string{"alice", "carol", "dave"}}
+	relay := outbox.NewRelay(
+		slog.New(slog.NewJSONHandler(io.Discard, nil)),
+		memoryRepository,
+		publisher,
+		time.Millisecond,
+		time.Minute,
+		2,
+		outbox.WithBackoff(time.Hour, time.Hour),
+	)
+
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		relay.Run(runCtx)
+		close(done)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	// the pairs backing off are not fetched again, so the newest pair gets its turn
+	require.Eventually(t, func() bool {
+		return len(publisher.publishedEvents()) == 1
+	}, time.Second, time.Millisecond)
+
+	require.Equal(t, "erin", publisher.publishedEvents()[0].ActorUserID)
+}
+
+// stallingPublisher stalls while publishing the first event until another relay takes the lease over.
+type stallingPublisher struct {
+	memoryRepository *repository.MemoryRepository
+	leaseDuration    time.Duration
+	published        int
+}
+
+func (sp *stallingPublisher) Publish(ctx context.Context, _ model.OutboxEvent) error {
+	if sp.published++; sp.published > 1 {
+		return nil
+	}
+
+	time.Sleep(2 * sp.leaseDuration)
+
+	_, _, err := sp.memoryRepository.AcquireOutboxLease(ctx, "other relay", time.Minute)
+
+	return err
+}
+
+func TestRelayStopsPublishingOnceLeaseIsTakenOver(t *testing.T) {
+	const leaseDuration = 10 * time.Millisecond
+
+	ctx := context.Background()
+	memoryRepository := repository.NewMemoryRepository()
+
+	decide(t, memoryRepository, "alice", "bob", model.DecisionLike)
+	decide(t, memoryRepository, "bob", "alice", model.DecisionLike)
+
+	publisher := &stallingPublisher{memoryRepository: memoryRepository, leaseDuration: leaseDuration}
+	relay := outbox.NewRelay(
+		slog.New(slog.NewJSONHandler(io.Discard, nil)),
+		memoryRepository,
+		publisher,
+		time.Millisecond,
+		leaseDuration,
+		10,
+	)
+
+	runCtx, cancel := context.WithTimeout(ctx, 20*leaseDuration)
+	defer cancel()
+
+	relay.Run(runCtx)
+
+	// the stalled relay can neither mark the event it published nor publish the later ones
+	require.Equal(t, 1, publisher.published)
+
+	events, err := memoryRepository.GetPendingOutboxEvents(ctx, math.MaxInt64)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	require.Zero(t, events[0].Attempts)
+}
+
+func TestRelayWaitsForLease(t *testing.T) {
+	ctx := context.Background()
+	memoryRepository := repository.NewMemoryRepository()
+
+	decide(t, memoryRepository, "alice", "bob", model.DecisionLike)
+
+	_, held, err := memoryRepository.AcquireOutboxLease(ctx, "other relay", time.Minute)
+	require.NoError(t, err)
+	require.True(t, held)
+
+	var output bytes.Buffer
+
+	relay := outbox.NewRelay(
+		slog.New(slog.NewJSONHandler(io.Discard, nil)),
+		memoryRepository,
+		outbox.NewWriterPublisher(&output),
+		time.Millisecond,
+		time.Minute,
+		10,
+	)
+
+	runCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+
+	relay.Run(runCtx)
+
+	require.Empty(t, output.String())
+
+	events, err := memoryRepository.GetPendingOutboxEvents(ctx, math.MaxInt64)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+}
+
+func TestWriterPublisherWritesJSONLines(t *testing.T) {
+	var output bytes.Buffer
+
+	publisher := outbox.NewWriterPublisher(&output)
+	occurredAt := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, eventType := range []model.OutboxEventType{model.OutboxEventLikeCreated, model.OutboxEventMatchCreated} {
+		err := publisher.Publish(context.Background(), model.OutboxEvent{
+			ID:              string(eventType),
+			Type:            eventType,
+			PairKey:         model.PairKey("bob", "alice"),
+			ActorUserID:     "bob",
+			RecipientUserID: "alice",
+			OccurredAt:      occurredAt,
+		})
+		require.NoError(t, err)
+	}
+
+	decoder := json.NewDecoder(&output)
+
+	for _, eventType := range []model.OutboxEventType{model.OutboxEventLikeCreated, model.OutboxEventMatchCreated} {
+		var event model.OutboxEvent
+
+		require.NoError(t, decoder.Decode(&event))
+		require.Equal(t, eventType, event.Type)
+		require.Equal(t, "alice:bob", event.PairKey)
+		require.Equal(t, occurredAt, event.OccurredAt)
+	}
+}
+
+func decide(
+	t *testing.T,
+	memoryRepository *repository.MemoryRepository,
+	actorID, recipientID string,
+	decision model.Decision,
+) {
+	t.Helper()
+
+	_, err := memoryRepository.MakeDecision(context.Background(), actorID, recipientID, decision, math.MaxInt64)
+	require.NoError(t, err)
+}
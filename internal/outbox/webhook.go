@@ -0,0 +1,318 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+)
+
+// PublisherWebhook delivers the events to the HTTP endpoints configured for their types.
+const PublisherWebhook = "webhook"
+
+const (
+	// SignatureHeader carries the signature of the payload, see Sign.
+	SignatureHeader = "X-Webhook-Signature"
+	// TimestampHeader carries the Unix time the payload was signed at.
+	TimestampHeader = "X-Webhook-Timestamp"
+	// EventIDHeader carries the ID of the event, which the receivers use to drop the events delivered more than once.
+	EventIDHeader = "X-Webhook-Event-ID"
+	// EventTypeHeader carries the type of the event.
+	EventTypeHeader = "X-Webhook-Event-Type"
+)
+
+// maxResponseSize is how much of the response body is read, so the connection can be reused.
+const maxResponseSize = 64 * 1024
+
+// errRejected is returned when the endpoint rejects the event for good, which is not retried.
+var errRejected = errors.New("event rejected")
+
+// errHeld is returned for the event not delivered to the endpoint while the earlier events of its pair are in the dead
+// letters of the endpoint, so the endpoint gets the events of every pair in order.
+var errHeld = errors.New("earlier events of the pair are in the dead letters")
+
+// DeadLetterStore keeps the deliveries that failed for good until they are replayed, and the endpoints done with the
+// events published again for the other endpoints.
+type DeadLetterStore interface {
+	// MarkOutboxEventDelivered records the endpoints done with the pending event, which are skipped when the event is
+	// published again.
+	MarkOutboxEventDelivered(ctx context.Context, eventID string, endpoints []string) error
+	SaveDeadLetter(ctx context.Context, deadLetter model.DeadLetter) error
+	// GetDeadLetters returns the dead letters with the IDs, or the given number of the oldest ones when no IDs are
+	// given, ordered by their events, the oldest first.
+	GetDeadLetters(ctx context.Context, deadLetterIDs []string, limit int64) ([]model.DeadLetter, error)
+	// HasEarlierDeadLetters reports whether the pair has dead letters for the endpoint of the events before the
+	// sequence.
+	HasEarlierDeadLetters(ctx context.Context, pairKey, endpoint string, sequence int64) (bool, error)
+	RemoveDeadLetter(ctx context.Context, deadLetterID string) error
+}
+
+// deadLetterNamespace derives the IDs of the dead letters from the event and the endpoint, so the delivery moved to
+// the dead letters again, after the relay failed to record it, replaces the stored dead letter.
+var deadLetterNamespace = uuid.MustParse("5f0c6a0e-8f2b-4d3a-9c51-6a4f3f0d2b7e")
+
+// WebhookPublisher posts every event as JSON to each endpoint configured for its type, signed with HMAC-SHA256.
+// Every publishing makes a single attempt for each endpoint not done with the event yet, and fails if some endpoint
+// should be retried, so the relay publishes the event again later with its backoff. The deliveries the endpoint
+// rejects with a client error other than 408 and 429, and those out of attempts, are moved to the dead letters and
+// can be replayed. While the pair has dead letters for the endpoint, its later events are moved to the dead letters
+// of the endpoint without delivering them, so they are replayed in order.
+type WebhookPublisher struct {
+	logger    *slog.Logger
+	client    *http.Client
+	store     DeadLetterStore
+	endpoints map[model.OutboxEventType][]string
+	secret    []byte
+	// maxAttempts is how many times the delivery is attempted before it is moved to the dead letters.
+	maxAttempts int64
+}
+
+func NewWebhookPublisher(
+	logger *slog.Logger,
+	client *http.Client,
+	store DeadLetterStore,
+	endpoints map[model.OutboxEventType][]string,
+	secret []byte,
+	maxAttempts int64,
+) *WebhookPublisher {
+	return &WebhookPublisher{
+		logger:      logger,
+		client:      client,
+		store:       store,
+		endpoints:   endpoints,
+		secret:      secret,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// ParseEndpoints splits the comma separated endpoints, ignoring the empty ones.
+func ParseEndpoints(endpoints string) []string {
+	var parsed []string
+
+	for _, endpoint := range strings.Split(endpoints, ",") {
+		if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+			parsed = append(parsed, endpoint)
+		}
+	}
+
+	return parsed
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the timestamp and the body joined by a dot, which is sent prefixed
+// with "sha256=" in SignatureHeader. Signing the timestamp lets the receivers reject the replayed old payloads.
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Publish implements Publisher. It fails if the event should be delivered to some endpoint again, or could neither
+// be delivered nor moved to the dead letters, in which case the event is published again to the endpoints not
+// recorded as done with it.
+func (wp *WebhookPublisher) Publish(ctx context.Context, event model.OutboxEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encoding outbox event: %w", err)
+	}
+
+	var (
+		delivered []string
+		retried   error
+	)
+
+	for _, endpoint := range wp.endpoints[event.Type] {
+		if slices.Contains(event.DeliveredTo, endpoint) {
+			continue
+		}
+
+		held, err := wp.store.HasEarlierDeadLetters(ctx, event.PairKey, endpoint, event.Sequence)
+		if err != nil {
+			return fmt.Errorf("checking dead letters of pair: %w", err)
+		}
+
+		attempts := event.Attempts + 1
+
+		if held {
+			attempts, err = event.Attempts, errHeld
+		} else {
+			err = wp.post(ctx, endpoint, event, body)
+		}
+
+		switch {
+		case err == nil:
+		case ctx.Err() != nil:
+			return err
+		case !held && !errors.Is(err, errRejected) && attempts < wp.maxAttempts:
+			retried = errors.Join(retried, fmt.Errorf("delivering to %s: %w", endpoint, err))
+
+			continue
+		default:
+			if err = wp.saveDeadLetter(ctx, event, endpoint, attempts, err); err != nil {
+				return err
+			}
+		}
+
+		delivered = append(delivered, endpoint)
+	}
+
+	if retried == nil {
+		return nil
+	}
+
+	if len(delivered) > 0 {
+		if err = wp.store.MarkOutboxEventDelivered(ctx, event.ID, delivered); err != nil {
+			return fmt.Errorf("marking outbox event delivered: %w", err)
+		}
+	}
+
+	return retried
+}
+
+func (wp *WebhookPublisher) saveDeadLetter(
+	ctx context.Context,
+	event model.OutboxEvent,
+	endpoint string,
+	attempts int64,
+	deliveryErr error,
+) error {
+	deadLetter := model.DeadLetter{
+		ID:        uuid.NewSHA1(deadLetterNamespace, []byte(event.ID+" "+endpoint)).String(),
+		Event:     event,
+		Endpoint:  endpoint,
+		Attempts:  attempts,
+		LastError: deliveryErr.Error(),
+		FailedAt:  time.Now().UTC().Truncate(time.Millisecond),
+	}
+
+	// the retry state of the event is kept by the relay, it is not part of the dead letter
+	deadLetter.Event.Attempts, deadLetter.Event.NextAttemptAt, deadLetter.Event.DeliveredTo = 0, time.Time{}, nil
+
+	if err := wp.store.SaveDeadLetter(ctx, deadLetter); err != nil {
+		return fmt.Errorf("saving failed delivery: %w", err)
+	}
+
+	wp.logger.Warn(
+		"failed delivering outbox event, moved it to the dead letters",
+		slog.String("dead_letter_id", deadLetter.ID),
+		slog.String("event_id", event.ID),
+		slog.String("endpoint", endpoint),
+		slog.Int64("attempts", deadLetter.Attempts),
+		slog.String("error", deadLetter.LastError),
+	)
+
+	return nil
+}
+
+// GetDeadLetters returns the dead letters to replay, see DeadLetterStore.GetDeadLetters.
+func (wp *WebhookPublisher) GetDeadLetters(
+	ctx context.Context,
+	deadLetterIDs []string,
+	limit int64,
+) ([]model.DeadLetter, error) {
+	return wp.store.GetDeadLetters(ctx, deadLetterIDs, limit)
+}
+
+// ReplayDeadLetter makes a single attempt of delivering the event of the dead letter to its endpoint again. It returns
+// the dead letter with the attempt of the replay added and whether it was delivered, the delivered dead letter is
+// removed and the other one is kept with the error of the replay. The dead letter is not delivered while the earlier
+// events of its pair are in the dead letters of the endpoint, so the dead letters of every pair are replayed in the
+// order of their events.
+func (wp *WebhookPublisher) ReplayDeadLetter(
+	ctx context.Context,
+	deadLetter model.DeadLetter,
+) (model.DeadLetter, bool, error) {
+	held, err := wp.store.HasEarlierDeadLetters(
+		ctx,
+		deadLetter.Event.PairKey,
+		deadLetter.Endpoint,
+		deadLetter.Event.Sequence,
+	)
+	if err != nil {
+		return deadLetter, false, fmt.Errorf("checking dead letters of pair: %w", err)
+	}
+
+	if held {
+		deadLetter.LastError = errHeld.Error()
+
+		return deadLetter, false, nil
+	}
+
+	body, err := json.Marshal(deadLetter.Event)
+	if err != nil {
+		return deadLetter, false, fmt.Errorf("encoding outbox event: %w", err)
+	}
+
+	deliveryErr := wp.post(ctx, deadLetter.Endpoint, deadLetter.Event, body)
+	deadLetter.Attempts++
+
+	if deliveryErr == nil {
+		deadLetter.LastError = ""
+
+		return deadLetter, true, wp.store.RemoveDeadLetter(ctx, deadLetter.ID)
+	}
+
+	if ctx.Err() != nil {
+		return deadLetter, false, deliveryErr
+	}
+
+	deadLetter.LastError = deliveryErr.Error()
+	deadLetter.FailedAt = time.Now().UTC().Truncate(time.Millisecond)
+
+	if err = wp.store.SaveDeadLetter(ctx, deadLetter); err != nil {
+		return deadLetter, false, fmt.Errorf("saving failed replay: %w", err)
+	}
+
+	return deadLetter, false, nil
+}
+
+func (wp *WebhookPublisher) post(ctx context.Context, endpoint string, event model.OutboxEvent, body []byte) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w: %w", errRejected, err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(EventIDHeader, event.ID)
+	request.Header.Set(EventTypeHeader, string(event.Type))
+	request.Header.Set(TimestampHeader, timestamp)
+	request.Header.Set(SignatureHeader, "sha256="+Sign(wp.secret, timestamp, body))
+
+	response, err := wp.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("sending request: %w", err)
+	}
+
+	defer func() {
+		_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, maxResponseSize))
+		_ = response.Body.Close()
+	}()
+
+	switch code := response.StatusCode; {
+	case code >= http.StatusOK && code < http.StatusMultipleChoices:
+		return nil
+	case code == http.StatusRequestTimeout || code == http.StatusTooManyRequests || code >= http.StatusInternalServerError:
+		return fmt.Errorf("unexpected status %d", code)
+	default:
+		return fmt.Errorf("%w with status %d", errRejected, code)
+	}
+}
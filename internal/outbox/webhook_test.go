@@ -0,0 +1,362 @@
+package outbox_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+	"github.com/PatrykPasterny/dating-engine/internal/outbox"
+	"github.com/PatrykPasterny/dating-engine/internal/repository"
+)
+
+var webhookSecret = []byte("webhook secret")
+
+// receiver answers the deliveries with the given status codes in turn, repeating the last one, and keeps the events
+// whose signature is valid.
+type receiver struct {
+	t        *testing.T
+	mu       sync.Mutex
+	codes    []int
+	attempts int
+	events   []model.OutboxEvent
+}
+
+func newReceiver(t *testing.T, codes ...int) (*receiver, string) {
+	r := &receiver{t: t, codes: codes}
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+
+	return r, server.URL
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, request *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// the handler runs on the goroutine of the server, so the failed checks do not stop the test right away
+	body, err := io.ReadAll(request.Body)
+	assert.NoError(r.t, err)
+
+	signature := "sha256=" + outbox.Sign(webhookSecret, request.Header.Get(outbox.TimestampHeader), body)
+	assert.True(r.t, hmac.Equal([]byte(signature), []byte(request.Header.Get(outbox.SignatureHeader))))
+
+	var event model.OutboxEvent
+
+	assert.NoError(r.t, json.Unmarshal(body, &event))
+	assert.Equal(r.t, event.ID, request.Header.Get(outbox.EventIDHeader))
+	assert.Equal(r.t, string(event.Type), request.Header.Get(outbox.EventTypeHeader))
+
+	code := r.codes[min(r.attempts, len(r.codes)-1)]
+	r.attempts++
+
+	if code == http.StatusOK {
+		r.events = append(r.events, event)
+	}
+
+	w.WriteHeader(code)
+}
+
+func (r *receiver) delivered() (int, []model.OutboxEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.attempts, r.events
+}
+
+func TestWebhookPublisherRetriesFailedDeliveryInLaterRounds(t *testing.T) {
+	matchReceiver, matchEndpoint := newReceiver(
+		t,
+		http.StatusServiceUnavailable,
+		http.StatusTooManyRequests,
+		http.StatusOK,
+	)
+	likeReceiver, likeEndpoint := newReceiver(t, http.StatusOK)
+	memoryRepository := repository.NewMemoryRepository()
+	publisher := newWebhookPublisher(memoryRepository, map[model.OutboxEventType][]string{
+		model.OutboxEventLikeCreated:  {likeEndpoint},
+		model.OutboxEventMatchCreated: {matchEndpoint, likeEndpoint},
+	})
+
+	decide(t, memoryRepository, "alice", "bob", model.DecisionLike)
+	decide(t, memoryRepository, "bob", "alice", model.DecisionLike)
+
+	event := pendingEvent(t, memoryRepository, model.OutboxEventMatchCreated)
+
+	// every publishing makes a single attempt, the endpoint that got the event is not posted to again
+	require.Error(t, publisher.Publish(context.Background(), event))
+	require.Error(t, publishAgain(t, memoryRepository, publisher, event.ID))
+	require.NoError(t, publishAgain(t, memoryRepository, publisher, event.ID))
+
+	attempts, events := matchReceiver.delivered()
+	require.Equal(t, 3, attempts)
+	require.Len(t, events, 1)
+	require.Equal(t, event.ID, events[0].ID)
+	require.Equal(t, event.OccurredAt, events[0].OccurredAt)
+
+	attempts, _ = likeReceiver.delivered()
+	require.Equal(t, 1, attempts)
+
+	// the events of the types without endpoints are not delivered anywhere
+	require.NoError(t, publisher.Publish(context.Background(), newOutboxEvent(model.OutboxEventMatchDissolved)))
+
+	attempts, _ = likeReceiver.delivered()
+	require.Equal(t, 1, attempts)
+
+	deadLetters, err := memoryRepository.GetDeadLetters(context.Background(), nil, math.MaxInt64)
+	require.NoError(t, err)
+	require.Empty(t, deadLetters)
+}
+
+func TestWebhookPublisherMovesFailedDeliveryToDeadLetters(t *testing.T) {
+	tests := []struct {
+		name             string
+		codes            []int
+		expectedAttempts int64
+	}{
+		{
+			name:             "attempts run out",
+			codes:            []int{http.StatusInternalServerError, http.StatusRequestTimeout, http.StatusBadGateway},
+			expectedAttempts: 3,
+		},
+		{name: "event rejected", codes: []int{http.StatusBadRequest}, expectedAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			receiver, endpoint := newReceiver(t, append(tt.codes, http.StatusOK)...)
+			memoryRepository := repository.NewMemoryRepository()
+			publisher := newWebhookPublisher(memoryRepository, map[model.OutboxEventType][]string{
+				model.OutboxEventLikeCreated: {endpoint},
+			})
+
+			decide(t, memoryRepository, "alice", "bob", model.DecisionLike)
+
+			event := pendingEvent(t, memoryRepository, model.OutboxEventLikeCreated)
+			err := publisher.Publish(ctx, event)
+
+			for range tt.expectedAttempts - 1 {
+				require.Error(t, err)
+
+				err = publishAgain(t, memoryRepository, publisher, event.ID)
+			}
+
+			// the delivery moved to the dead letters does not fail the publishing
+			require.NoError(t, err)
+
+			deadLetters, err := publisher.GetDeadLetters(ctx, nil, math.MaxInt64)
+			require.NoError(t, err)
+			require.Len(t, deadLetters, 1)
+			require.Equal(t, event, deadLetters[0].Event)
+			require.Equal(t, endpoint, deadLetters[0].Endpoint)
+			require.Equal(t, tt.expectedAttempts, deadLetters[0].Attempts)
+			require.NotEmpty(t, deadLetters[0].LastError)
+
+			replayed, delivered, err := publisher.ReplayDeadLetter(ctx, deadLetters[0])
+			require.NoError(t, err)
+			require.True(t, delivered)
+			require.Equal(t, tt.expectedAttempts+1, replayed.Attempts)
+
+			_, events := receiver.delivered()
+			require.Len(t, events, 1)
+			require.Equal(t, event.ID, events[0].ID)
+
+			deadLetters, err = publisher.GetDeadLetters(ctx, nil, math.MaxInt64)
+			require.NoError(t, err)
+			require.Empty(t, deadLetters)
+		})
+	}
+}
+
+func TestWebhookPublisherKeepsDeadLetterFailingReplay(t *testing.T) {
+	ctx := context.Background()
+	receiver, endpoint := newReceiver(t, http.StatusBadGateway)
+	memoryRepository := repository.NewMemoryRepository()
+	publisher := newWebhookPublisher(memoryRepository, map[model.OutboxEventType][]string{
+		model.OutboxEventLikeCreated: {endpoint},
+	})
+
+	decide(t, memoryRepository, "alice", "bob", model.DecisionLike)
+
+	event := pendingEvent(t, memoryRepository, model.OutboxEventLikeCreated)
+	require.Error(t, publisher.Publish(ctx, event))
+	require.Error(t, publishAgain(t, memoryRepository, publisher, event.ID))
+	require.NoError(t, publishAgain(t, memoryRepository, publisher, event.ID))
+
+	deadLetters, err := publisher.GetDeadLetters(ctx, nil, math.MaxInt64)
+	require.NoError(t, err)
+	require.Len(t, deadLetters, 1)
+
+	// the replay makes a single attempt as well
+	replayed, delivered, err := publisher.ReplayDeadLetter(ctx, deadLetters[0])
+	require.NoError(t, err)
+	require.False(t, delivered)
+	require.Equal(t, int64(4), replayed.Attempts)
+	require.Equal(t, "unexpected status 502", replayed.LastError)
+
+	attempts, _ := receiver.delivered()
+	require.Equal(t, 4, attempts)
+
+	deadLetters, err = publisher.GetDeadLetters(ctx, nil, math.MaxInt64)
+	require.NoError(t, err)
+	require.Equal(t, []model.DeadLetter{replayed}, deadLetters)
+}
+
+func TestWebhookPublisherHoldsLaterEventsOfPairBehindDeadLetters(t *testing.T) {
+	ctx := context.Background()
+	receiver, endpoint := newReceiver(t, http.StatusBadRequest, http.StatusOK)
+	memoryRepository := repository.NewMemoryRepository()
+	publisher := newWebhookPublisher(memoryRepository, map[model.OutboxEventType][]string{
+		model.OutboxEventLikeCreated:  {endpoint},
+		model.OutboxEventMatchCreated: {endpoint},
+	})
+
+	decide(t, memoryRepository, "alice", "bob", model.DecisionLike)
+	decide(t, memoryRepository, "bob", "alice", model.DecisionLike)
+	decide(t, memoryRepository, "carol", "bob", model.DecisionLike)
+
+	events, err := memoryRepository.GetPendingOutboxEvents(ctx, math.MaxInt64)
+	require.NoError(t, err)
+
+	// the first event of alice and bob is rejected, so their later events are not delivered while it is dead
+	pairKey := model.PairKey("alice", "bob")
+	pairEvents := make([]model.OutboxEvent, 0, 3)
+
+	var otherEvent model.OutboxEvent
+
+	for _, event := range events {
+		if event.PairKey == pairKey {
+			pairEvents = append(pairEvents, event)
+		} else {
+			otherEvent = event
+		}
+	}
+
+	require.Len(t, pairEvents, 3)
+
+	for _, event := range pairEvents {
+		require.NoError(t, publisher.Publish(ctx, event))
+	}
+
+	attempts, _ := receiver.delivered()
+	require.Equal(t, 1, attempts)
+
+	// the other pairs are delivered as usual
+	require.NoError(t, publisher.Publish(ctx, otherEvent))
+
+	deadLetters, err := publisher.GetDeadLetters(ctx, nil, math.MaxInt64)
+	require.NoError(t, err)
+	require.Len(t, deadLetters, 3)
+
+	for i, deadLetter := range deadLetters {
+		require.Equal(t, pairEvents[i].ID, deadLetter.Event.ID)
+	}
+
+	require.Equal(t, int64(1), deadLetters[0].Attempts)
+	require.Equal(t, int64(0), deadLetters[2].Attempts)
+
+	// the later dead letters are not delivered before the earlier ones are
+	replayed, delivered, err := publisher.ReplayDeadLetter(ctx, deadLetters[2])
+	require.NoError(t, err)
+	require.False(t, delivered)
+	require.Equal(t, int64(0), replayed.Attempts)
+	require.NotEmpty(t, replayed.LastError)
+
+	for _, deadLetter := range deadLetters {
+		_, delivered, err = publisher.ReplayDeadLetter(ctx, deadLetter)
+		require.NoError(t, err)
+		require.True(t, delivered)
+	}
+
+	attempts, received := receiver.delivered()
+	require.Equal(t, 5, attempts)
+	require.Len(t, received, 4)
+	require.Equal(t, otherEvent.ID, received[0].ID)
+
+	for i, event := range pairEvents {
+		require.Equal(t, event.ID, received[i+1].ID)
+	}
+}
+
+func newWebhookPublisher(
+	store outbox.DeadLetterStore,
+	endpoints map[model.OutboxEventType][]string,
+) *outbox.WebhookPublisher {
+	return outbox.NewWebhookPublisher(
+		slog.New(slog.NewJSONHandler(io.Discard, nil)),
+		http.DefaultClient,
+		store,
+		endpoints,
+		webhookSecret,
+		3,
+	)
+}
+
+func newOutboxEvent(eventType model.OutboxEventType) model.OutboxEvent {
+	return model.OutboxEvent{
+		ID:              string(eventType) + "-event",
+		Type:            eventType,
+		PairKey:         model.PairKey("alice", "bob"),
+		Sequence:        1,
+		ActorUserID:     "alice",
+		RecipientUserID: "bob",
+		OccurredAt:      time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+// pendingEvent returns the first pending event of the type.
+func pendingEvent(
+	t *testing.T,
+	memoryRepository *repository.MemoryRepository,
+	eventType model.OutboxEventType,
+) model.OutboxEvent {
+	t.Helper()
+
+	events, err := memoryRepository.GetPendingOutboxEvents(context.Background(), math.MaxInt64)
+	require.NoError(t, err)
+
+	i := slices.IndexFunc(events, func(event model.OutboxEvent) bool {
+		return event.Type == eventType
+	})
+	require.GreaterOrEqual(t, i, 0)
+
+	return events[i]
+}
+
+// publishAgain records the failed attempt of publishing the event the way the relay does, and publishes it again.
+func publishAgain(
+	t *testing.T,
+	memoryRepository *repository.MemoryRepository,
+	publisher *outbox.WebhookPublisher,
+	eventID string,
+) error {
+	t.Helper()
+
+	leaseTerm, held, err := memoryRepository.AcquireOutboxLease(context.Background(), "relay", time.Minute)
+	require.NoError(t, err)
+	require.True(t, held)
+	require.NoError(t, memoryRepository.RetryOutboxEvent(context.Background(), eventID, leaseTerm, time.Now()))
+
+	events, err := memoryRepository.GetPendingOutboxEvents(context.Background(), math.MaxInt64)
+	require.NoError(t, err)
+
+	i := slices.IndexFunc(events, func(event model.OutboxEvent) bool {
+		return event.ID == eventID
+	})
+	require.GreaterOrEqual(t, i, 0)
+
+	return publisher.Publish(context.Background(), events[i])
+}
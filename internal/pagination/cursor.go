@@ -0,0 +1,211 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	// cursorVersion 2 added the list the token was issued for.
+	cursorVersion   byte = 2
+	cursorKeyLength      = 32
+)
+
+// cursorList is the list the token was issued for, the token is accepted only on the same list.
+type cursorList byte
+
+const (
+	likersList  cursorList = 1
+	reportsList cursorList = 2
+)
+
+// Cursor is the content of the pagination token, it holds everything needed to retrieve the next page.
+type Cursor struct {
+	// UserID is the recipient whose likers are listed, the token is accepted only on their list.
+	UserID   string
+	Sort     Sort
+	Order    Order
+	Position Position
+}
+
+// cursorPayload only gains optional fields within a version of the tokens, so the tokens of the same version issued
+// before the fields were added are still valid. Bumping cursorVersion invalidates all the tokens issued before, as
+// version 2 did with the version 1 tokens, so the clients holding them list the likers from the first page again.
+type cursorPayload struct {
+	UserID      string `json:"u"`
+	Sort        Sort   `json:"s"`
+	Order       Order  `json:"o"`
+	SuperLiked  bool   `json:"p,omitempty"`
+	LikedAt     int64  `json:"t,omitempty"`
+	ActorUserID string `json:"a"`
+}
+
+// ReportCursor is the content of the pagination token of the moderation queue.
+type ReportCursor struct {
+	// State is the state the reports are listed in, the token is accepted only on the list of the same state.
+	State    string
+	Position ReportPosition
+}
+
+type reportCursorPayload struct {
+	State     string `json:"s,omitempty"`
+	CreatedAt int64  `json:"t"`
+	ReportID  string `json:"r"`
+}
+
+// CursorCodec turns cursors into opaque pagination tokens and back. Tokens are signed, so the ones that were
+// not issued by the codec, or were modified by the client, are rejected.
+type CursorCodec struct {
+	key []byte
+}
+
+// NewCursorCodec creates the codec signing tokens with the given key. When the key is empty a random one is
+// generated, which makes the tokens valid only within the running instance.
+func NewCursorCodec(key []byte) (*CursorCodec, error) {
+	if len(key) == 0 {
+		key = make([]byte, cursorKeyLength)
+
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("generating cursor key: %w", err)
+		}
+	}
+
+	return &CursorCodec{
+		key: key,
+	}, nil
+}
+
+// Encode returns the token of the liker list in the form of base64(version | list | payload | signature).
+func (cc *CursorCodec) Encode(cursor Cursor) (string, error) {
+	payload := cursorPayload{
+		UserID:      cursor.UserID,
+		Sort:        cursor.Sort,
+		Order:       cursor.Order,
+		SuperLiked:  cursor.Position.SuperLiked,
+		ActorUserID: cursor.Position.ActorUserID,
+	}
+
+	if !cursor.Position.LikedAt.IsZero() {
+		payload.LikedAt = cursor.Position.LikedAt.UnixMilli()
+	}
+
+	return cc.seal(likersList, payload)
+}
+
+// Decode returns the cursor held by the token of the liker list or ErrInvalidToken if the token is malformed,
+// forged or issued for another list.
+func (cc *CursorCodec) Decode(token string) (Cursor, error) {
+	var payload cursorPayload
+
+	if err := cc.open(token, likersList, &payload); err != nil {
+		return Cursor{}, err
+	}
+
+	if err := payload.Sort.Validate(); err != nil {
+		return Cursor{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	if err := payload.Order.Validate(); err != nil {
+		return Cursor{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	cursor := Cursor{
+		UserID: payload.UserID,
+		Sort:   payload.Sort,
+		Order:  payload.Order,
+		Position: Position{
+			SuperLiked:  payload.SuperLiked,
+			ActorUserID: payload.ActorUserID,
+		},
+	}
+
+	if payload.LikedAt != 0 {
+		cursor.Position.LikedAt = time.UnixMilli(payload.LikedAt).UTC()
+	}
+
+	return cursor, nil
+}
+
+// EncodeReports returns the token of the moderation queue in the same form as Encode.
+func (cc *CursorCodec) EncodeReports(cursor ReportCursor) (string, error) {
+	return cc.seal(reportsList, reportCursorPayload{
+		State:     cursor.State,
+		CreatedAt: cursor.Position.CreatedAt.UnixMilli(),
+		ReportID:  cursor.Position.ReportID,
+	})
+}
+
+// DecodeReports returns the cursor held by the token of the moderation queue or ErrInvalidToken if the token is
+// malformed, forged or issued for another list.
+func (cc *CursorCodec) DecodeReports(token string) (ReportCursor, error) {
+	var payload reportCursorPayload
+
+	if err := cc.open(token, reportsList, &payload); err != nil {
+		return ReportCursor{}, err
+	}
+
+	return ReportCursor{
+		State: payload.State,
+		Position: ReportPosition{
+			CreatedAt: time.UnixMilli(payload.CreatedAt).UTC(),
+			ReportID:  payload.ReportID,
+		},
+	}, nil
+}
+
+// seal returns the signed token holding the payload of the list.
+func (cc *CursorCodec) seal(list cursorList, payload any) (string, error) {
+	encodedPayload, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("marshalling cursor: %w", err)
+	}
+
+	token := append([]byte{cursorVersion, byte(list)}, encodedPayload...)
+	token = append(token, cc.sign(token)...)
+
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// open verifies the token was issued for the list and unmarshals its payload.
+func (cc *CursorCodec) open(token string, list cursorList, payload any) error {
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return fmt.Errorf("%w: decoding token: %v", ErrInvalidToken, err)
+	}
+
+	if len(decoded) <= 2+sha256.Size {
+		return fmt.Errorf("%w: token too short", ErrInvalidToken)
+	}
+
+	if decoded[0] != cursorVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidToken, decoded[0])
+	}
+
+	signed, signature := decoded[:len(decoded)-sha256.Size], decoded[len(decoded)-sha256.Size:]
+
+	if !hmac.Equal(signature, cc.sign(signed)) {
+		return fmt.Errorf("%w: signature mismatch", ErrInvalidToken)
+	}
+
+	if cursorList(signed[1]) != list {
+		return fmt.Errorf("%w: issued for another list", ErrInvalidToken)
+	}
+
+	if err = json.Unmarshal(signed[2:], payload); err != nil {
+		return fmt.Errorf("%w: unmarshalling cursor: %v", ErrInvalidToken, err)
+	}
+
+	return nil
+}
+
+func (cc *CursorCodec) sign(data []byte) []byte {
+	mac := hmac.New(sha256.New, cc.key)
+	mac.Write(data)
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,77 @@
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Sort is the key the likers are ordered by.
+type Sort string
+
+const (
+	// SortByActor orders likers by their user ID.
+	SortByActor Sort = "actor"
+	// SortByTime orders likers by the time of the like, using their user ID to break ties.
+	SortByTime Sort = "time"
+)
+
+// Order is the direction the likers are ordered in.
+type Order string
+
+const (
+	Ascending  Order = "asc"
+	Descending Order = "desc"
+)
+
+var ErrInvalidToken = errors.New("invalid pagination token")
+
+// Position is the last liker returned on the previous page.
+type Position struct {
+	SuperLiked  bool
+	LikedAt     time.Time
+	ActorUserID string
+}
+
+// Page describes which likers should be retrieved.
+type Page struct {
+	Sort  Sort
+	Order Order
+	Limit int64
+	// SuperLikesFirst places the super likes before the other likes, which are then ordered by the sort.
+	SuperLikesFirst bool
+	// After is the position the page starts after, it is nil for the first page.
+	After *Position
+}
+
+func (s Sort) Validate() error {
+	switch s {
+	case SortByActor, SortByTime:
+		return nil
+	default:
+		return fmt.Errorf("unknown sort %q", s)
+	}
+}
+
+func (o Order) Validate() error {
+	switch o {
+	case Ascending, Descending:
+		return nil
+	default:
+		return fmt.Errorf("unknown order %q", o)
+	}
+}
+
+// ReportPage describes which reports of the moderation queue should be retrieved. The reports are ordered by the
+// time they were made, the oldest first.
+type ReportPage struct {
+	Limit int64
+	// After is the last report returned on the previous page, it is nil for the first page.
+	After *ReportPosition
+}
+
+// ReportPosition is the last report returned on the previous page.
+type ReportPosition struct {
+	CreatedAt time.Time
+	ReportID  string
+}
@@ -0,0 +1,12 @@
+package repository
+
+import "github.com/PatrykPasterny/dating-engine/internal/model"
+
+// counterpartOf returns the user on the other side of the block than the user.
+func counterpartOf(block *model.Block, userID string) string {
+	if block.BlockerUserID == userID {
+		return block.BlockedUserID
+	}
+
+	return block.BlockerUserID
+}
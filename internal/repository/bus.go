@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+)
+
+// errFellBehind is returned by the subscription that did not keep up with the publishers of the bus.
+var errFellBehind = fmt.Errorf("events of the subscription are no longer kept: %w", ErrNotFound)
+
+// Subscription delivers the events of a single user in the order they were committed.
+type Subscription interface {
+	// Next waits for the next event of the user, returning it with the token resuming the subscription after it.
+	Next(ctx context.Context) (model.Event, string, error)
+	Close(ctx context.Context) error
+}
+
+// EventBus delivers the events published in the process to the subscriptions of their users. It keeps only the
+// latest events, so a subscription can be resumed after a while without a replica set to watch, but not after the
+// process restarts.
+type EventBus struct {
+	mu        sync.Mutex
+	retention int
+	// events are the kept events, the oldest first, numbered up to next.
+	events []busEvent
+	next   uint64
+	// published is closed and replaced whenever events are published, waking up the waiting subscriptions.
+	published chan struct{}
+	// err fails the subscriptions once the bus no longer receives the events.
+	err error
+}
+
+// busEvent is the kept event with the token resuming the subscriptions after it.
+type busEvent struct {
+	event       model.Event
+	resumeToken string
+}
+
+// NewEventBus returns the bus keeping the given number of the latest events.
+func NewEventBus(retention int) *EventBus {
+	return &EventBus{
+		retention: retention,
+		next:      1,
+		published: make(chan struct{}),
+	}
+}
+
+// Publish delivers the events to the subscriptions of their users without waiting for them.
+func (eb *EventBus) Publish(events ...model.Event) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	for _, event := range events {
+		eb.publish(event, strconv.FormatUint(eb.next, 10))
+	}
+
+	eb.wakeUp()
+}
+
+// publishResumable delivers the event resumed after with the given token to the subscriptions of its user.
+func (eb *EventBus) publishResumable(event model.Event, resumeToken string) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	eb.publish(event, resumeToken)
+	eb.wakeUp()
+}
+
+// fail fails the subscriptions, which no longer get the events published after the error.
+func (eb *EventBus) fail(err error) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	eb.err = err
+	eb.wakeUp()
+}
+
+func (eb *EventBus) publish(event model.Event, resumeToken string) {
+	eb.events = append(eb.events, busEvent{event: event, resumeToken: resumeToken})
+	eb.next++
+
+	if excess := len(eb.events) - eb.retention; excess > 0 {
+		eb.events = slices.Delete(eb.events, 0, excess)
+	}
+}
+
+// wakeUp wakes up the subscriptions waiting for the events.
+func (eb *EventBus) wakeUp() {
+	close(eb.published)
+	eb.published = make(chan struct{})
+}
+
+// SubscribeEvents subscribes to the events of the user published after the one the resume token was returned with,
+// or after the subscription if the token is empty. It returns ErrNotFound if the events after the token are no
+// longer kept.
+func (eb *EventBus) SubscribeEvents(ctx context.Context, userID, resumeToken string) (Subscription, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, wrapError("subscribing to events", err)
+	}
+
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	subscription := &busSubscription{
+		bus:    eb,
+		userID: userID,
+		after:  eb.next - 1,
+	}
+
+	if resumeToken == "" {
+		return subscription, nil
+	}
+
+	sequence, err := strconv.ParseUint(resumeToken, 10, 64)
+	if err != nil || sequence >= eb.next {
+		return nil, &InvalidInputError{Field: "resume_token", Description: "unknown resume token"}
+	}
+
+	if sequence+1 < eb.first() {
+		return nil, fmt.Errorf("events after the resume token are no longer kept: %w", ErrNotFound)
+	}
+
+	subscription.after = sequence
+
+	return subscription, nil
+}
+
+// subscribeAfterResumeToken subscribes to the events of the user published after the kept one the resume token was
+// returned with, it reports false if no kept event was.
+func (eb *EventBus) subscribeAfterResumeToken(userID, resumeToken string) (Subscription, bool) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	i := slices.IndexFunc(eb.events, func(event busEvent) bool {
+		return event.resumeToken == resumeToken
+	})
+	if i < 0 {
+		return nil, false
+	}
+
+	return &busSubscription{bus: eb, userID: userID, after: eb.first() + uint64(i)}, true
+}
+
+// subscribeAfter subscribes to the events of the user published after the event with the number.
+func (eb *EventBus) subscribeAfter(userID string, sequence uint64) Subscription {
+	return &busSubscription{bus: eb, userID: userID, after: sequence}
+}
+
+// latest returns the number of the latest published event and the token resuming the subscriptions after it, which
+// is empty once the event is no longer kept.
+func (eb *EventBus) latest() (uint64, string) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	if len(eb.events) == 0 {
+		return eb.next - 1, ""
+	}
+
+	return eb.next - 1, eb.events[len(eb.events)-1].resumeToken
+}
+
+// first returns the number of the oldest kept event.
+func (eb *EventBus) first() uint64 {
+	return eb.next - uint64(len(eb.events))
+}
+
+type busSubscription struct {
+	bus    *EventBus
+	userID string
+	// after is the number of the latest event the subscription went through.
+	after uint64
+}
+
+func (bs *busSubscription) Next(ctx context.Context) (model.Event, string, error) {
+	for {
+		event, sequence, published, err := bs.nextPublished()
+		if err != nil {
+			return model.Event{}, "", err
+		}
+
+		if sequence != 0 {
+			return event.event, event.resumeToken, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return model.Event{}, "", wrapError("waiting for events", ctx.Err())
+		case <-published:
+		}
+	}
+}
+
+// nextPublished returns the next published event of the user and its number, or zero and the channel closed once
+// more events are published if there is no such event yet.
+func (bs *busSubscription) nextPublished() (busEvent, uint64, <-chan struct{}, error) {
+	bs.bus.mu.Lock()
+	defer bs.bus.mu.Unlock()
+
+	if bs.bus.err != nil {
+		return busEvent{}, 0, nil, bs.bus.err
+	}
+
+	first := bs.bus.first()
+
+	// the subscription did not keep up with the publishers, so it cannot tell which events it missed
+	if bs.after+1 < first {
+		return busEvent{}, 0, nil, errFellBehind
+	}
+
+	for sequence := bs.after + 1; sequence < bs.bus.next; sequence++ {
+		bs.after = sequence
+
+		if event := bs.bus.events[sequence-first]; event.event.UserID == bs.userID {
+			return event, sequence, nil, nil
+		}
+	}
+
+	return busEvent{}, 0, bs.bus.published, nil
+}
+
+func (bs *busSubscription) Close(context.Context) error {
+	return nil
+}
@@ -0,0 +1,46 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+	"github.com/PatrykPasterny/dating-engine/internal/repository"
+)
+
+func TestEventBusForgetsOldestEvents(t *testing.T) {
+	bus := repository.NewEventBus(2)
+
+	subscription, err := bus.SubscribeEvents(context.Background(), "recipient", "")
+	require.NoError(t, err)
+
+	bus.Publish(model.Event{ID: "first", UserID: "recipient"}, model.Event{ID: "other", UserID: "other"})
+
+	event, resumeToken, err := subscription.Next(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "first", event.ID)
+
+	bus.Publish(model.Event{ID: "second", UserID: "recipient"}, model.Event{ID: "third", UserID: "recipient"})
+
+	// the events after the first one are only partially kept, so resuming after it would miss the second one
+	_, err = bus.SubscribeEvents(context.Background(), "recipient", resumeToken)
+	require.ErrorIs(t, err, repository.ErrNotFound)
+
+	_, _, err = subscription.Next(context.Background())
+	require.ErrorIs(t, err, repository.ErrNotFound)
+
+	_, err = bus.SubscribeEvents(context.Background(), "recipient", "42")
+	require.ErrorIs(t, err, repository.ErrInvalidInput)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	subscription, err = bus.SubscribeEvents(context.Background(), "recipient", "")
+	require.NoError(t, err)
+
+	_, _, err = subscription.Next(ctx)
+	require.ErrorIs(t, err, repository.ErrDeadline)
+}
@@ -0,0 +1,344 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+)
+
+// changeStreamRetention is the number of the latest events of the shared change stream kept for its subscriptions.
+const changeStreamRetention = 4096
+
+// eventStream watches the events collection through a single change stream shared by all the subscriptions of the
+// repository, fanning the events out to them through a bus. The change stream is watched only while there are
+// subscriptions.
+type eventStream struct {
+	events *mongo.Collection
+	mu     sync.Mutex
+	// watch is the running change stream, nil while there are no subscriptions or once it failed.
+	watch *eventWatch
+}
+
+// eventWatch is a single run of the shared change stream.
+type eventWatch struct {
+	bus *EventBus
+	// resumeToken resumes the change stream at the point it started watching.
+	resumeToken   string
+	cancel        context.CancelFunc
+	subscriptions int
+}
+
+func newEventStream(events *mongo.Collection) *eventStream {
+	return &eventStream{events: events}
+}
+
+// acquire returns the running shared change stream for another subscription, watching the events collection first
+// if it is not running yet.
+func (es *eventStream) acquire(ctx context.Context) (*eventWatch, error) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	if es.watch == nil {
+		stream, err := es.events.Watch(ctx, insertedEvents(""))
+		if err != nil {
+			return nil, wrapError("watching events", err)
+		}
+
+		// the change stream outlives the request starting it and is stopped once its last subscription is closed
+		watchCtx, cancel := context.WithCancel(context.Background())
+		es.watch = &eventWatch{
+			bus:         NewEventBus(changeStreamRetention),
+			resumeToken: encodeResumeToken(stream.ResumeToken()),
+			cancel:      cancel,
+		}
+
+		go es.run(watchCtx, es.watch, stream)
+	}
+
+	es.watch.subscriptions++
+
+	return es.watch, nil
+}
+
+// release stops the change stream once it has no subscriptions left.
+func (es *eventStream) release(watch *eventWatch) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	watch.subscriptions--
+
+	if watch.subscriptions > 0 {
+		return
+	}
+
+	watch.cancel()
+
+	if es.watch == watch {
+		es.watch = nil
+	}
+}
+
+// run publishes the events of the change stream to the bus of the watch until the watch is stopped, failing the
+// subscriptions if the change stream fails, so they are resumed on another one.
+func (es *eventStream) run(ctx context.Context, watch *eventWatch, stream *mongo.ChangeStream) {
+	defer func() {
+		_ = stream.Close(context.Background())
+	}()
+
+	err := func() error {
+		for stream.Next(ctx) {
+			event, resumeToken, err := decodeChange(stream)
+			if err != nil {
+				return err
+			}
+
+			watch.bus.publishResumable(event, resumeToken)
+		}
+
+		return watchError(ctx, stream)
+	}()
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	es.mu.Lock()
+
+	if es.watch == watch {
+		es.watch = nil
+	}
+
+	es.mu.Unlock()
+
+	watch.bus.fail(err)
+}
+
+// SubscribeEvents subscribes to the events of the user recorded after the one the resume token was returned with,
+// or after the subscription if the token is empty. The subscriptions share a single change stream of the events
+// collection, whose resume tokens are handed out. A subscription resumed after an event the shared change stream no
+// longer keeps catches up through a change stream of its own, so it can be resumed for as long as the oplog of the
+// replica set keeps the events. It returns ErrNotFound once the oplog no longer does.
+func (er *ExploreRepository) SubscribeEvents(
+	ctx context.Context,
+	userID, resumeToken string,
+) (Subscription, error) {
+	if resumeToken != "" {
+		if _, err := decodeResumeToken(resumeToken); err != nil {
+			return nil, err
+		}
+	}
+
+	watch, err := er.eventStream.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := &sharedSubscription{
+		events:      er.eventStream,
+		watch:       watch,
+		userID:      userID,
+		resumeToken: resumeToken,
+	}
+
+	if resumeToken == "" {
+		var latest uint64
+
+		latest, subscription.resumeToken = watch.bus.latest()
+		if subscription.resumeToken == "" {
+			subscription.resumeToken = watch.resumeToken
+		}
+
+		subscription.subscription = watch.bus.subscribeAfter(userID, latest)
+
+		return subscription, nil
+	}
+
+	if busSubscription, ok := watch.bus.subscribeAfterResumeToken(userID, resumeToken); ok {
+		subscription.subscription = busSubscription
+
+		return subscription, nil
+	}
+
+	if err = subscription.catchUp(ctx); err != nil {
+		er.eventStream.release(watch)
+
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// sharedSubscription delivers the events of the user from the shared change stream. Resumed after an event the
+// shared change stream no longer keeps, or falling behind it, the subscription watches a change stream of its own
+// until it catches up.
+type sharedSubscription struct {
+	events *eventStream
+	watch  *eventWatch
+	userID string
+	// resumeToken resumes the subscription after the latest event it delivered.
+	resumeToken string
+	// subscription delivers the events of the shared change stream, it is nil while the subscription catches up.
+	subscription Subscription
+	// own is the change stream of the subscription catching up, nil once it caught up.
+	own    *mongo.ChangeStream
+	closed bool
+}
+
+func (ss *sharedSubscription) Next(ctx context.Context) (model.Event, string, error) {
+	if ss.own != nil {
+		// the events published by the shared change stream after the latest one are not missed, so once the own
+		// change stream has none left the subscription continues after it
+		latest, _ := ss.watch.bus.latest()
+
+		if ss.own.TryNext(ctx) {
+			return ss.delivered(decodeChange(ss.own))
+		}
+
+		if err := ss.own.Err(); err != nil {
+			return model.Event{}, "", wrapError("watching events", err)
+		}
+
+		if err := ss.closeOwn(ctx); err != nil {
+			return model.Event{}, "", err
+		}
+
+		ss.subscription = ss.watch.bus.subscribeAfter(ss.userID, latest)
+	}
+
+	event, resumeToken, err := ss.subscription.Next(ctx)
+	if errors.Is(err, errFellBehind) && ss.resumeToken != "" {
+		if err = ss.catchUp(ctx); err != nil {
+			return model.Event{}, "", err
+		}
+
+		return ss.Next(ctx)
+	}
+
+	return ss.delivered(event, resumeToken, err)
+}
+
+func (ss *sharedSubscription) Close(ctx context.Context) error {
+	if ss.closed {
+		return nil
+	}
+
+	ss.closed = true
+	ss.events.release(ss.watch)
+
+	if ss.own != nil {
+		return ss.closeOwn(ctx)
+	}
+
+	return nil
+}
+
+// catchUp watches the events of the user after the latest delivered one through a change stream of the subscription.
+func (ss *sharedSubscription) catchUp(ctx context.Context) error {
+	token, err := decodeResumeToken(ss.resumeToken)
+	if err != nil {
+		return err
+	}
+
+	ss.own, err = ss.events.events.Watch(ctx, insertedEvents(ss.userID), options.ChangeStream().SetStartAfter(token))
+	if err != nil {
+		return wrapError("watching events", err)
+	}
+
+	ss.subscription = nil
+
+	return nil
+}
+
+func (ss *sharedSubscription) closeOwn(ctx context.Context) error {
+	own := ss.own
+	ss.own = nil
+
+	if err := own.Close(ctx); err != nil {
+		return wrapError("closing change stream", err)
+	}
+
+	return nil
+}
+
+// delivered keeps the resume token of the event delivered by the subscription.
+func (ss *sharedSubscription) delivered(event model.Event, resumeToken string, err error) (model.Event, string, error) {
+	if err == nil {
+		ss.resumeToken = resumeToken
+	}
+
+	return event, resumeToken, err
+}
+
+// insertedEvents returns the pipeline of the change stream of the inserted events, only of the user if one is given.
+func insertedEvents(userID string) mongo.Pipeline {
+	filters := bson.D{
+		{
+			Key: "operationType", Value: "insert",
+		},
+	}
+
+	if userID != "" {
+		filters = append(filters, bson.E{Key: "fullDocument.userID", Value: userID})
+	}
+
+	return mongo.Pipeline{
+		{
+			{
+				Key: "$match", Value: filters,
+			},
+		},
+	}
+}
+
+// decodeChange returns the event of the current change of the change stream and the token resuming after it.
+func decodeChange(stream *mongo.ChangeStream) (model.Event, string, error) {
+	var change struct {
+		FullDocument model.Event `bson:"fullDocument"`
+	}
+
+	if err := stream.Decode(&change); err != nil {
+		return model.Event{}, "", wrapError("decoding event", err)
+	}
+
+	return change.FullDocument, encodeResumeToken(stream.ResumeToken()), nil
+}
+
+// watchError returns the error that stopped the change stream.
+func watchError(ctx context.Context, stream *mongo.ChangeStream) error {
+	err := stream.Err()
+	if err == nil {
+		err = ctx.Err()
+	}
+
+	if err == nil {
+		err = errors.New("change stream closed")
+	}
+
+	return wrapError("watching events", err)
+}
+
+// encodeResumeToken returns the resume token of the change stream handed out by the subscription.
+func encodeResumeToken(token bson.Raw) string {
+	return base64.RawURLEncoding.EncodeToString(token)
+}
+
+// decodeResumeToken returns the resume token of the change stream handed out by the subscription.
+func decodeResumeToken(resumeToken string) (bson.Raw, error) {
+	token, err := base64.RawURLEncoding.DecodeString(resumeToken)
+	if err == nil {
+		err = bson.Raw(token).Validate()
+	}
+
+	if err != nil {
+		return nil, &InvalidInputError{Field: "resume_token", Description: "malformed resume token"}
+	}
+
+	return token, nil
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
+)
+
+// The errors returned by the repository wrap one of the following errors describing the kind of the failure,
+// so the callers can react to it without knowing the underlying storage. Errors not wrapping any of them are
+// unexpected failures.
+var (
+	// ErrNotFound is returned when the requested entity does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is returned when the change conflicts with the current state or a concurrent change,
+	// retrying it may succeed.
+	ErrConflict = errors.New("conflict")
+	// ErrInvalidInput is returned when the storage rejected the given input, retrying it won't succeed.
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrUnavailable is returned when the storage could not be reached, retrying it may succeed.
+	ErrUnavailable = errors.New("unavailable")
+	// ErrDeadline is returned when the operation did not finish in time.
+	ErrDeadline = errors.New("deadline exceeded")
+	// ErrLimitExceeded is returned when the user has used up the allowance of the operation, retrying it won't
+	// succeed until the allowance is renewed.
+	ErrLimitExceeded = errors.New("limit exceeded")
+	// ErrForbidden is returned when the users are not allowed to interact with each other, e.g. because one of them
+	// blocked the other.
+	ErrForbidden = errors.New("forbidden")
+)
+
+// InvalidInputError describes which input was rejected.
+type InvalidInputError struct {
+	Field       string
+	Description string
+}
+
+func (e *InvalidInputError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Description)
+}
+
+func (e *InvalidInputError) Unwrap() error {
+	return ErrInvalidInput
+}
+
+const (
+	mongoWriteConflictCode       = 112
+	mongoDocumentValidationCode  = 121
+	mongoMaxTimeMSExpiredCode    = 50
+	mongoInterruptedAtShutdown   = 11600
+	mongoNotWritablePrimaryCode  = 10107
+	mongoNotPrimaryNoSecondaryOk = 13435
+	mongoChangeStreamFatalError  = 280
+	mongoChangeStreamHistoryLost = 286
+)
+
+// wrapError adds the message to the error, classifying the mongo error with one of the repository errors.
+func wrapError(message string, err error) error {
+	if kind := errorKind(err); kind != nil {
+		return fmt.Errorf("%s: %w: %w", message, kind, err)
+	}
+
+	return fmt.Errorf("%s: %w", message, err)
+}
+
+func errorKind(err error) error {
+	var (
+		serverError          mongo.ServerError
+		serverSelectionError topology.ServerSelectionError
+	)
+
+	switch {
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrConflict), errors.Is(err, ErrInvalidInput),
+		errors.Is(err, ErrUnavailable), errors.Is(err, ErrDeadline), errors.Is(err, ErrLimitExceeded),
+		errors.Is(err, ErrForbidden):
+		// already classified
+		return nil
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return ErrNotFound
+	case errors.Is(err, context.DeadlineExceeded), mongo.IsTimeout(err):
+		return ErrDeadline
+	case mongo.IsDuplicateKeyError(err):
+		return ErrConflict
+	case mongo.IsNetworkError(err), errors.Is(err, mongo.ErrClientDisconnected),
+		errors.As(err, &serverSelectionError):
+		return ErrUnavailable
+	case errors.As(err, &serverError):
+		return serverErrorKind(serverError)
+	default:
+		return nil
+	}
+}
+
+func serverErrorKind(err mongo.ServerError) error {
+	switch {
+	case err.HasErrorCode(mongoWriteConflictCode), err.HasErrorLabel("TransientTransactionError"):
+		return ErrConflict
+	case err.HasErrorCode(mongoDocumentValidationCode):
+		return ErrInvalidInput
+	case err.HasErrorCode(mongoMaxTimeMSExpiredCode):
+		return ErrDeadline
+	case err.HasErrorCode(mongoInterruptedAtShutdown), err.HasErrorCode(mongoNotWritablePrimaryCode),
+		err.HasErrorCode(mongoNotPrimaryNoSecondaryOk), err.HasErrorLabel("RetryableWriteError"):
+		return ErrUnavailable
+	case err.HasErrorCode(mongoChangeStreamFatalError), err.HasErrorCode(mongoChangeStreamHistoryLost):
+		// the change stream cannot be resumed, as the oplog no longer holds the changes after the resume token
+		return ErrNotFound
+	default:
+		return nil
+	}
+}
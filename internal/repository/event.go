@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+)
+
+// matchEvents returns the events the change of the match notifies its recipient about. A like that matches the
+// users is delivered as the match alone.
+func matchEvents(before, after *model.Match, at time.Time) []model.Event {
+	var kind model.EventKind
+
+	switch {
+	case after.Matched && !before.Matched:
+		kind = model.EventKindMatch
+	case before.Matched && !after.Matched:
+		kind = model.EventKindUnmatch
+	case after.Liked && !after.Matched && (!before.Liked || after.SuperLiked() && !before.SuperLiked()):
+		kind = model.EventKindLike
+	default:
+		return nil
+	}
+
+	return []model.Event{
+		{
+			ID:          uuid.NewString(),
+			Kind:        kind,
+			UserID:      after.RecipientUserID,
+			OtherUserID: after.ActorUserID,
+			SuperLiked:  kind == model.EventKindLike && after.SuperLiked(),
+			At:          at,
+		},
+	}
+}
+
+// dissolvedEvents returns the events notifying both users that their match was dissolved.
+func dissolvedEvents(userID, matchedUserID string, at time.Time) []model.Event {
+	return []model.Event{
+		{
+			ID:          uuid.NewString(),
+			Kind:        model.EventKindUnmatch,
+			UserID:      matchedUserID,
+			OtherUserID: userID,
+			At:          at,
+		},
+		{
+			ID:          uuid.NewString(),
+			Kind:        model.EventKindUnmatch,
+			UserID:      userID,
+			OtherUserID: matchedUserID,
+			At:          at,
+		},
+	}
+}
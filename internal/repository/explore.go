@@ -2,43 +2,139 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
+	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/PatrykPasterny/dating-engine/internal/model"
+	"github.com/PatrykPasterny/dating-engine/internal/pagination"
 )
 
+// notUnmatchedFilter excludes the pairs whose match was dissolved from the liker lists of both users.
+var notUnmatchedFilter = bson.E{
+	Key: "unmatch", Value: bson.D{
+		{
+			Key: "$exists", Value: false,
+		},
+	},
+}
+
+// superLikeRetention is how long the daily super like counters are kept, it only has to outlast the day.
+const superLikeRetention = 48 * time.Hour
+
+// outboxLeaseID is the ID of the single lease of publishing the outbox events.
+const outboxLeaseID = "relay"
+
+var tracer = otel.Tracer("github.com/PatrykPasterny/dating-engine/internal/repository")
+
+// TransactionObserver is notified about the decision transactions that did not go through at the first attempt.
+type TransactionObserver interface {
+	// TransactionRetried is called whenever the transaction is run again after a transient error.
+	TransactionRetried()
+	// TransactionAborted is called when the transaction is given up on.
+	TransactionAborted()
+}
+
+type noopTransactionObserver struct{}
+
+func (noopTransactionObserver) TransactionRetried() {}
+
+func (noopTransactionObserver) TransactionAborted() {}
+
+// Collections are the collections the repository stores its data in.
+type Collections struct {
+	Matches *mongo.Collection
+	// SuperLikes counts the super likes of every actor per day.
+	SuperLikes *mongo.Collection
+	// Decisions keeps the history of the decisions of every actor, so they can be undone.
+	Decisions *mongo.Collection
+	// Blocks keeps the users every user blocked.
+	Blocks *mongo.Collection
+	// Reports keeps the reports of the users waiting for or after the review.
+	Reports *mongo.Collection
+	// ReportedUsers keeps the number of users that reported every reported user and whether the reports hide them.
+	ReportedUsers *mongo.Collection
+	// Events receives the events of every change of the likes, written in the same transaction as the change.
+	Events *mongo.Collection
+	// Outbox receives the domain events for the other services in the same transaction as the change.
+	Outbox *mongo.Collection
+	// OutboxSequences numbers the outbox events of every pair of users.
+	OutboxSequences *mongo.Collection
+	// OutboxLeases keeps which relay publishes the outbox events.
+	OutboxLeases *mongo.Collection
+	// DeadLetters keeps the webhook deliveries of the outbox events that failed for good.
+	DeadLetters *mongo.Collection
+}
+
 type ExploreRepository struct {
-	mongoClient *mongo.Client
-	collection  *mongo.Collection
+	mongoClient         *mongo.Client
+	collection          *mongo.Collection
+	superLikes          *mongo.Collection
+	decisions           *mongo.Collection
+	blocks              *mongo.Collection
+	reports             *mongo.Collection
+	reportedUsers       *mongo.Collection
+	events              *mongo.Collection
+	outbox              *mongo.Collection
+	outboxSequences     *mongo.Collection
+	outboxLeases        *mongo.Collection
+	deadLetters         *mongo.Collection
+	eventStream         *eventStream
+	transactionObserver TransactionObserver
+}
+
+// Option changes how the repository is created.
+type Option func(er *ExploreRepository)
+
+// WithTransactionObserver sets the observer of the decision transactions.
+func WithTransactionObserver(observer TransactionObserver) Option {
+	return func(er *ExploreRepository) {
+		er.transactionObserver = observer
+	}
 }
 
-func NewExploreRepository(mongoClient *mongo.Client, collection *mongo.Collection) *ExploreRepository {
-	return &ExploreRepository{
-		mongoClient: mongoClient,
-		collection:  collection,
+func NewExploreRepository(mongoClient *mongo.Client, collections Collections, opts ...Option) *ExploreRepository {
+	er := &ExploreRepository{
+		mongoClient:         mongoClient,
+		collection:          collections.Matches,
+		superLikes:          collections.SuperLikes,
+		decisions:           collections.Decisions,
+		blocks:              collections.Blocks,
+		reports:             collections.Reports,
+		reportedUsers:       collections.ReportedUsers,
+		events:              collections.Events,
+		outbox:              collections.Outbox,
+		outboxSequences:     collections.OutboxSequences,
+		outboxLeases:        collections.OutboxLeases,
+		deadLetters:         collections.DeadLetters,
+		eventStream:         newEventStream(collections.Events),
+		transactionObserver: noopTransactionObserver{},
+	}
+
+	for _, opt := range opts {
+		opt(er)
 	}
+
+	return er
 }
 
 func (er *ExploreRepository) GetLikedUser(
 	ctx context.Context,
-	userID, paginationToken string,
-	limit int64,
+	userID string,
+	page pagination.Page,
 ) ([]model.Match, error) {
-	findOptions := options.Find().SetSort(
-		bson.D{
-			{
-				Key:   "actorUserID",
-				Value: 1,
-			},
-		},
-	).SetLimit(limit)
-
-	fmt.Println(userID)
-
 	filters := bson.D{
 		{
 			Key: "recipientUserID", Value: userID,
@@ -46,24 +142,17 @@ func (er *ExploreRepository) GetLikedUser(
 		{
 			Key: "liked", Value: true,
 		},
-		{
-			Key: "actorUserID", Value: bson.D{
-				{
-					Key: "$gt", Value: paginationToken,
-				},
-			},
-		},
+		notUnmatchedFilter,
 	}
 
-	cur, err := er.collection.Find(ctx, filters, findOptions)
+	filters, err := er.withoutExcluded(ctx, userID, filters)
 	if err != nil {
 		return nil, fmt.Errorf("finding users that liked the user: %w", err)
 	}
 
-	var likedUser []model.Match
-
-	if err = cur.All(ctx, &likedUser); err != nil {
-		return nil, fmt.Errorf("retrieving all users that liked the user: %w", err)
+	likedUser, err := er.findLikers(ctx, filters, page)
+	if err != nil {
+		return nil, fmt.Errorf("finding users that liked the user: %w", err)
 	}
 
 	return likedUser, nil
@@ -71,18 +160,9 @@ func (er *ExploreRepository) GetLikedUser(
 
 func (er *ExploreRepository) GetNewLikedUser(
 	ctx context.Context,
-	userID, paginationToken string,
-	limit int64,
+	userID string,
+	page pagination.Page,
 ) ([]model.Match, error) {
-	findOptions := options.Find().SetSort(
-		bson.D{
-			{
-				Key:   "actorUserID",
-				Value: 1,
-			},
-		},
-	).SetLimit(limit)
-
 	filters := bson.D{
 		{
 			Key: "recipientUserID", Value: userID,
@@ -90,30 +170,191 @@ func (er *ExploreRepository) GetNewLikedUser(
 		{
 			Key: "liked", Value: true,
 		},
+		notUnmatchedFilter,
 		{
 			Key: "matched", Value: false,
 		},
+	}
+
+	filters, err := er.withoutExcluded(ctx, userID, filters)
+	if err != nil {
+		return nil, fmt.Errorf("finding new users that liked the user: %w", err)
+	}
+
+	newLikedUser, err := er.findLikers(ctx, filters, page)
+	if err != nil {
+		return nil, fmt.Errorf("finding new users that liked the user: %w", err)
+	}
+
+	return newLikedUser, nil
+}
+
+// findLikers finds the page of the likers selected by the filters. The super likes listed first are found by a query
+// of their own, so that every query is sorted only by the time or the actor, which keeps the pagination consistent
+// with the likes stored before the decisions were.
+func (er *ExploreRepository) findLikers(
+	ctx context.Context,
+	filters bson.D,
+	page pagination.Page,
+) ([]model.Match, error) {
+	if !page.SuperLikesFirst {
+		return er.findPage(ctx, filters, page)
+	}
+
+	var likers []model.Match
+
+	if page.After == nil || page.After.SuperLiked {
+		superLikers, err := er.findPage(ctx, append(slices.Clone(filters), superLikedFilter(true)), page)
+		if err != nil {
+			return nil, err
+		}
+
+		if page.Limit > 0 && int64(len(superLikers)) >= page.Limit {
+			return superLikers, nil
+		}
+
+		// the rest of the page is filled with the other likes from their beginning
+		likers = superLikers
+		page.After = nil
+
+		if page.Limit > 0 {
+			page.Limit -= int64(len(superLikers))
+		}
+	}
+
+	others, err := er.findPage(ctx, append(slices.Clone(filters), superLikedFilter(false)), page)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(likers, others...), nil
+}
+
+func (er *ExploreRepository) findPage(
+	ctx context.Context,
+	filters bson.D,
+	page pagination.Page,
+) ([]model.Match, error) {
+	if filter, ok := pageFilter(page); ok {
+		filters = append(filters, filter)
+	}
+
+	pipeline := mongo.Pipeline{
+		{
+			{
+				Key: "$match", Value: filters,
+			},
+		},
 		{
-			Key: "actorUserID", Value: bson.D{
-				{
-					Key: "$gt", Value: paginationToken,
-				},
+			{
+				Key: "$sort", Value: pageSort(page),
 			},
 		},
 	}
 
-	cur, err := er.collection.Find(ctx, filters, findOptions)
+	pipeline = append(pipeline, er.notHiddenStages()...)
+
+	if page.Limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: page.Limit}})
+	}
+
+	cur, err := er.collection.Aggregate(ctx, pipeline)
 	if err != nil {
-		return nil, fmt.Errorf("finding new users that liked the user: %w", err)
+		return nil, wrapError("finding likers", err)
 	}
 
-	var newLikedUser []model.Match
+	var likers []model.Match
 
-	if err = cur.All(ctx, &newLikedUser); err != nil {
-		return nil, fmt.Errorf("retrieving all new users that liked the user: %w", err)
+	if err = cur.All(ctx, &likers); err != nil {
+		return nil, wrapError("retrieving likers", err)
 	}
 
-	return newLikedUser, nil
+	return likers, nil
+}
+
+// withoutExcluded adds the filter excluding the users that blocked the user or were blocked by them. The blocks of a
+// user are few, so they are found first and excluded from the likers by their identifiers.
+func (er *ExploreRepository) withoutExcluded(ctx context.Context, userID string, filters bson.D) (bson.D, error) {
+	blocksFilters := bson.D{
+		{
+			Key: "$or",
+			Value: bson.A{
+				bson.D{{Key: "blockerUserID", Value: userID}},
+				bson.D{{Key: "blockedUserID", Value: userID}},
+			},
+		},
+	}
+
+	cur, err := er.blocks.Find(ctx, blocksFilters)
+	if err != nil {
+		return nil, wrapError("finding blocks of the user", err)
+	}
+
+	var blocks []model.Block
+
+	if err = cur.All(ctx, &blocks); err != nil {
+		return nil, wrapError("retrieving blocks of the user", err)
+	}
+
+	if len(blocks) == 0 {
+		return filters, nil
+	}
+
+	excludedUserIDs := make([]string, 0, len(blocks))
+
+	for _, block := range blocks {
+		excludedUserIDs = append(excludedUserIDs, counterpartOf(&block, userID))
+	}
+
+	excludeFilter := bson.E{
+		Key: "actorUserID",
+		Value: bson.D{
+			{
+				Key: "$nin", Value: excludedUserIDs,
+			},
+		},
+	}
+
+	return append(filters, excludeFilter), nil
+}
+
+// notHiddenStages leave out the likers hidden by the reports. The reported user is looked up by its ID for every
+// liker the query goes through, so the cost grows with the likers of the user rather than with the hidden users.
+func (er *ExploreRepository) notHiddenStages() mongo.Pipeline {
+	return mongo.Pipeline{
+		{
+			{
+				Key: "$lookup", Value: bson.D{
+					{
+						Key: "from", Value: er.reportedUsers.Name(),
+					},
+					{
+						Key: "localField", Value: "actorUserID",
+					},
+					{
+						Key: "foreignField", Value: "_id",
+					},
+					{
+						Key: "as", Value: "reportedActor",
+					},
+				},
+			},
+		},
+		{
+			{
+				Key: "$match", Value: bson.D{
+					{
+						Key: "reportedActor.hidden", Value: bson.D{{Key: "$ne", Value: true}},
+					},
+				},
+			},
+		},
+		{
+			{
+				Key: "$project", Value: bson.D{{Key: "reportedActor", Value: 0}},
+			},
+		},
+	}
 }
 
 func (er *ExploreRepository) CountLikedUser(ctx context.Context, userID string) (uint64, error) {
@@ -124,115 +365,1677 @@ func (er *ExploreRepository) CountLikedUser(ctx context.Context, userID string)
 		{
 			Key: "liked", Value: true,
 		},
+		notUnmatchedFilter,
 	}
 
-	count, err := er.collection.CountDocuments(ctx, filters)
+	filters, err := er.withoutExcluded(ctx, userID, filters)
 	if err != nil {
 		return 0, fmt.Errorf("counting users that liked the user: %w", err)
 	}
 
-	return uint64(count), nil
+	pipeline := mongo.Pipeline{
+		{
+			{
+				Key: "$match", Value: filters,
+			},
+		},
+	}
+
+	pipeline = append(pipeline, er.notHiddenStages()...)
+	pipeline = append(pipeline, bson.D{{Key: "$count", Value: "count"}})
+
+	cur, err := er.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, wrapError("counting users that liked the user", err)
+	}
+
+	// $count returns no document at all when nothing is counted
+	var counts []struct {
+		Count uint64 `bson:"count"`
+	}
+
+	if err = cur.All(ctx, &counts); err != nil {
+		return 0, wrapError("retrieving count of users that liked the user", err)
+	}
+
+	if len(counts) == 0 {
+		return 0, nil
+	}
+
+	return counts[0].Count, nil
 }
 
+// MakeDecision records the decision of the user on the recipient and returns whether they like each other and whether
+// the decision made their match. A super like fails with ErrLimitExceeded if the user has already made superLikeLimit
+// super likes today, repeating the super like on the same recipient is not counted again.
 func (er *ExploreRepository) MakeDecision(
 	ctx context.Context,
 	userID, recipientID string,
-	decision bool,
-) (bool, error) {
-	findOptions := options.FindOne()
+	decision model.Decision,
+	superLikeLimit int64,
+) (model.DecisionResult, error) {
+	ctx, span := tracer.Start(ctx, "ExploreRepository.MakeDecision", trace.WithAttributes(
+		attribute.String("decision", decision.String()),
+	))
+	defer span.End()
 
-	userFilters := bson.D{
+	result, err := er.runTransaction(ctx, span, func(sc mongo.SessionContext) (interface{}, error) {
+		return er.makeDecision(sc, userID, recipientID, decision, superLikeLimit)
+	})
+	if err != nil {
+		return model.DecisionResult{}, err
+	}
+
+	return result.(model.DecisionResult), nil
+}
+
+// UndoDecision reverts the latest decision of the user if it was made within the window, restoring the matches of
+// both users as they were before it and giving back the super like the decision used. It returns the undone decision
+// or ErrNotFound if there is no decision to undo.
+func (er *ExploreRepository) UndoDecision(
+	ctx context.Context,
+	userID string,
+	window time.Duration,
+) (model.DecisionRecord, error) {
+	ctx, span := tracer.Start(ctx, "ExploreRepository.UndoDecision")
+	defer span.End()
+
+	record, err := er.runTransaction(ctx, span, func(sc mongo.SessionContext) (interface{}, error) {
+		return er.undoDecision(sc, userID, window)
+	})
+	if err != nil {
+		return model.DecisionRecord{}, err
+	}
+
+	return record.(model.DecisionRecord), nil
+}
+
+// Unmatch dissolves the match of the users, recording on both sides who dissolved it, when and why. The users never
+// match or see each other in the liker lists again. It returns ErrNotFound if the users are not matched.
+func (er *ExploreRepository) Unmatch(
+	ctx context.Context,
+	userID, matchedUserID string,
+	reason model.UnmatchReason,
+) error {
+	ctx, span := tracer.Start(ctx, "ExploreRepository.Unmatch", trace.WithAttributes(
+		attribute.String("reason", string(reason)),
+	))
+	defer span.End()
+
+	_, err := er.runTransaction(ctx, span, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, er.unmatch(sc, userID, matchedUserID, reason)
+	})
+
+	return err
+}
+
+// BlockUser blocks the user for the user, dissolving their match if they are matched. The users no longer see each
+// other in the liker lists and cannot decide about each other until the block is lifted. Blocking the user again
+// keeps the time of the original block.
+func (er *ExploreRepository) BlockUser(ctx context.Context, userID, blockedUserID string) error {
+	ctx, span := tracer.Start(ctx, "ExploreRepository.BlockUser")
+	defer span.End()
+
+	_, err := er.runTransaction(ctx, span, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, er.block(sc, userID, blockedUserID)
+	})
+
+	return err
+}
+
+// UnblockUser lifts the block of the user, it returns ErrNotFound if the user did not block the other one. A match
+// dissolved by the block is not restored.
+func (er *ExploreRepository) UnblockUser(ctx context.Context, userID, blockedUserID string) error {
+	blockFilters := bson.D{
 		{
-			Key: "actorUserID", Value: userID,
+			Key: "blockerUserID", Value: userID,
 		},
 		{
-			Key: "recipientUserID", Value: recipientID,
+			Key: "blockedUserID", Value: blockedUserID,
 		},
 	}
 
-	recipientFilters := bson.D{
+	result, err := er.blocks.DeleteOne(ctx, blockFilters)
+	if err != nil {
+		return wrapError("unblocking user", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return fmt.Errorf("user is not blocked: %w", ErrNotFound)
+	}
+
+	return nil
+}
+
+// GetBlockedUsers returns the blocks of the users the user blocked, the latest first.
+func (er *ExploreRepository) GetBlockedUsers(ctx context.Context, userID string) ([]model.Block, error) {
+	findOptions := options.Find().SetSort(bson.D{
 		{
-			Key: "recipientUserID", Value: userID,
+			Key: "createdAt", Value: -1,
 		},
 		{
-			Key: "actorUserID", Value: recipientID,
+			Key: "blockedUserID", Value: 1,
 		},
-	}
+	})
 
-	session, err := er.mongoClient.StartSession()
+	cur, err := er.blocks.Find(ctx, bson.D{{Key: "blockerUserID", Value: userID}}, findOptions)
 	if err != nil {
-		return false, fmt.Errorf("starting new mongo session: %w", err)
+		return nil, wrapError("finding users blocked by the user", err)
 	}
 
-	var mutualLikes bool
+	blocks := make([]model.Block, 0)
 
-	if err = session.StartTransaction(); err != nil {
-		return false, fmt.Errorf("starting new mongo transaction: %w", err)
+	if err = cur.All(ctx, &blocks); err != nil {
+		return nil, wrapError("retrieving users blocked by the user", err)
 	}
-	if err = mongo.WithSession(ctx, session, func(sc mongo.SessionContext) error {
-		userResult := er.collection.FindOne(ctx, userFilters, findOptions)
-		if userResult.Err() != nil {
-			return fmt.Errorf("finding user that made new decision: %w", userResult.Err())
-		}
 
-		recipientResult := er.collection.FindOne(ctx, recipientFilters, findOptions)
-		if recipientResult.Err() != nil {
-			return fmt.Errorf("finding user that recieved new decision: %w", recipientResult.Err())
-		}
+	return blocks, nil
+}
 
-		var userMatch, recipientMatch model.Match
+// ReportUser stores the report of the reporter in the moderation queue and blocks the reported user for the reporter.
+// Once hideThreshold users have open reports about the reported user, the user is hidden from the liker lists of
+// the others until enough of the reports are resolved, a zero threshold never hides anyone. It returns the stored
+// report and whether it hid the reported user.
+func (er *ExploreRepository) ReportUser(
+	ctx context.Context,
+	report model.Report,
+	hideThreshold int64,
+) (model.ReportResult, error) {
+	ctx, span := tracer.Start(ctx, "ExploreRepository.ReportUser", trace.WithAttributes(
+		attribute.String("reason", string(report.Reason)),
+	))
+	defer span.End()
 
-		if err = userResult.Decode(&userMatch); err != nil {
-			return fmt.Errorf("decoding user that made new decision: %w", err)
-		}
+	result, err := er.runTransaction(ctx, span, func(sc mongo.SessionContext) (interface{}, error) {
+		return er.reportUser(sc, report, hideThreshold)
+	})
+	if err != nil {
+		return model.ReportResult{}, err
+	}
 
-		if err = recipientResult.Decode(&recipientMatch); err != nil {
-			return fmt.Errorf("decoding user that recieved new decision: %w", err)
-		}
+	return result.(model.ReportResult), nil
+}
+
+// GetReports returns the page of the reports in the state, the reports in every state if it is empty.
+func (er *ExploreRepository) GetReports(
+	ctx context.Context,
+	state model.ReportState,
+	page pagination.ReportPage,
+) ([]model.Report, error) {
+	var filters bson.D
 
-		mutualLikes = decision && recipientMatch.Liked
+	if state != "" {
+		filters = append(filters, bson.E{Key: "state", Value: state})
+	}
 
-		updateUser := bson.D{
-			{
-				Key: "$set",
-				Value: bson.D{
+	if page.After != nil {
+		// the reports made at the same time are ordered by their IDs
+		filters = append(filters, bson.E{
+			Key: "$or",
+			Value: bson.A{
+				bson.D{
 					{
-						Key:   "liked",
-						Value: decision,
+						Key: "createdAt", Value: bson.D{{Key: "$gt", Value: page.After.CreatedAt}},
 					},
+				},
+				bson.D{
 					{
-						Key:   "matched",
-						Value: mutualLikes,
+						Key: "createdAt", Value: page.After.CreatedAt,
 					},
-				},
-			},
-		}
-
-		updateRecipient := bson.D{
-			{
-				Key: "$set",
-				Value: bson.D{
 					{
-						Key:   "matched",
-						Value: mutualLikes,
+						Key: "_id", Value: bson.D{{Key: "$gt", Value: page.After.ReportID}},
 					},
 				},
 			},
-		}
+		})
+	}
 
-		if _, err = er.collection.UpdateOne(ctx, userFilters, updateUser, options.Update()); err != nil {
-			return fmt.Errorf("updating user with new decision: %w", err)
-		}
+	findOptions := options.Find().SetSort(bson.D{
+		{
+			Key: "createdAt", Value: 1,
+		},
+		{
+			Key: "_id", Value: 1,
+		},
+	})
 
-		if _, err = er.collection.UpdateOne(ctx, recipientFilters, updateRecipient, options.Update()); err != nil {
-			return fmt.Errorf("updating recipient with new decision: %w", err)
-		}
+	if page.Limit > 0 {
+		findOptions.SetLimit(page.Limit)
+	}
 
-		return nil
-	}); err != nil {
-		return false, fmt.Errorf("performing mongo transaction: %w", err)
+	if filters == nil {
+		filters = bson.D{}
+	}
+
+	cur, err := er.reports.Find(ctx, filters, findOptions)
+	if err != nil {
+		return nil, wrapError("finding reports", err)
+	}
+
+	reports := make([]model.Report, 0)
+
+	if err = cur.All(ctx, &reports); err != nil {
+		return nil, wrapError("retrieving reports", err)
+	}
+
+	return reports, nil
+}
+
+// ResolveReport moves the open report into the state after the review, hideThreshold is applied to the reports left
+// open about the reported user like in ReportUser. It returns the resolved report and whether it showed the reported
+// user again, or ErrNotFound if there is no open report with the ID.
+func (er *ExploreRepository) ResolveReport(
+	ctx context.Context,
+	reportID string,
+	state model.ReportState,
+	note string,
+	hideThreshold int64,
+) (model.ReportResult, error) {
+	ctx, span := tracer.Start(ctx, "ExploreRepository.ResolveReport", trace.WithAttributes(
+		attribute.String("state", string(state)),
+	))
+	defer span.End()
+
+	result, err := er.runTransaction(ctx, span, func(sc mongo.SessionContext) (interface{}, error) {
+		return er.resolveReport(sc, reportID, state, note, hideThreshold)
+	})
+	if err != nil {
+		return model.ReportResult{}, err
+	}
+
+	return result.(model.ReportResult), nil
+}
+
+// GetPendingOutboxEvents returns the outbox events not published yet of the pairs of the given number of the oldest
+// such events that are due, skipping the events waiting for the backoff of a failed attempt, so the failing pairs do
+// not hold up the others. All the pending events of these pairs are returned, ordered by the pair and their sequence,
+// so the events of every pair can be published in order.
+func (er *ExploreRepository) GetPendingOutboxEvents(ctx context.Context, limit int64) ([]model.OutboxEvent, error) {
+	pendingFilter := bson.E{
+		Key: "publishedAt", Value: bson.D{
+			{
+				Key: "$exists", Value: false,
+			},
+		},
 	}
-	session.EndSession(ctx)
 
-	return mutualLikes, nil
+	oldestOptions := options.Find().
+		SetSort(bson.D{
+			{
+				Key: "occurredAt", Value: 1,
+			},
+			{
+				Key: "_id", Value: 1,
+			},
+		}).
+		SetLimit(limit).
+		SetProjection(bson.D{{Key: "pairKey", Value: 1}})
+
+	dueFilter := bson.E{
+		Key: "$or", Value: bson.A{
+			bson.D{
+				{
+					Key: "nextAttemptAt", Value: bson.D{
+						{
+							Key: "$exists", Value: false,
+						},
+					},
+				},
+			},
+			bson.D{
+				{
+					Key: "nextAttemptAt", Value: bson.D{
+						{
+							Key: "$lte", Value: time.Now().UTC(),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cursor, err := er.outbox.Find(ctx, bson.D{pendingFilter, dueFilter}, oldestOptions)
+	if err != nil {
+		return nil, wrapError("finding oldest pending outbox events", err)
+	}
+
+	var oldest []model.OutboxEvent
+
+	if err = cursor.All(ctx, &oldest); err != nil {
+		return nil, wrapError("decoding oldest pending outbox events", err)
+	}
+
+	events := make([]model.OutboxEvent, 0)
+
+	if len(oldest) == 0 {
+		return events, nil
+	}
+
+	pairKeys := make([]string, 0, len(oldest))
+	for _, event := range oldest {
+		if !slices.Contains(pairKeys, event.PairKey) {
+			pairKeys = append(pairKeys, event.PairKey)
+		}
+	}
+
+	pairsFilter := bson.D{
+		pendingFilter,
+		{
+			Key: "pairKey", Value: bson.D{
+				{
+					Key: "$in", Value: pairKeys,
+				},
+			},
+		},
+	}
+
+	pairsOptions := options.Find().SetSort(bson.D{
+		{
+			Key: "pairKey", Value: 1,
+		},
+		{
+			Key: "sequence", Value: 1,
+		},
+	})
+
+	cursor, err = er.outbox.Find(ctx, pairsFilter, pairsOptions)
+	if err != nil {
+		return nil, wrapError("finding pending outbox events of pairs", err)
+	}
+
+	if err = cursor.All(ctx, &events); err != nil {
+		return nil, wrapError("decoding pending outbox events of pairs", err)
+	}
+
+	return events, nil
+}
+
+// MarkOutboxEventPublished records that the pending outbox event was published, the published events are removed by
+// MongoDB after a while. The event is only marked while the lease is held in the given term.
+func (er *ExploreRepository) MarkOutboxEventPublished(ctx context.Context, eventID string, leaseTerm int64) error {
+	markPublished := bson.D{
+		{
+			Key: "$set",
+			Value: bson.D{
+				{
+					Key: "publishedAt", Value: time.Now().UTC().Truncate(time.Millisecond),
+				},
+			},
+		},
+	}
+
+	return er.updateLeasedOutboxEvent(ctx, "marking outbox event published", eventID, leaseTerm, markPublished)
+}
+
+// RetryOutboxEvent records the failed attempt of publishing the pending outbox event, which is published again at
+// the given time at the earliest. The attempt is only recorded while the lease is held in the given term.
+func (er *ExploreRepository) RetryOutboxEvent(
+	ctx context.Context,
+	eventID string,
+	leaseTerm int64,
+	nextAttemptAt time.Time,
+) error {
+	recordAttempt := bson.D{
+		{
+			Key: "$inc",
+			Value: bson.D{
+				{
+					Key: "attempts", Value: 1,
+				},
+			},
+		},
+		{
+			Key: "$set",
+			Value: bson.D{
+				{
+					Key: "nextAttemptAt", Value: nextAttemptAt.UTC().Truncate(time.Millisecond),
+				},
+			},
+		},
+	}
+
+	return er.updateLeasedOutboxEvent(ctx, "retrying outbox event", eventID, leaseTerm, recordAttempt)
+}
+
+// MarkOutboxEventDelivered records the webhook endpoints done with the pending outbox event, which are skipped when
+// the event is published again for the other endpoints.
+func (er *ExploreRepository) MarkOutboxEventDelivered(ctx context.Context, eventID string, endpoints []string) error {
+	addEndpoints := bson.D{
+		{
+			Key: "$addToSet",
+			Value: bson.D{
+				{
+					Key: "deliveredTo", Value: bson.D{
+						{
+							Key: "$each", Value: endpoints,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return er.updatePendingOutboxEvent(ctx, "marking outbox event delivered", eventID, addEndpoints)
+}
+
+// updateLeasedOutboxEvent updates the pending outbox event in a transaction writing to the lease held in the term, so
+// the update fails once another relay took the lease over, even if it does so while the event is being updated.
+func (er *ExploreRepository) updateLeasedOutboxEvent(
+	ctx context.Context,
+	operation, eventID string,
+	leaseTerm int64,
+	update bson.D,
+) error {
+	session, err := er.mongoClient.StartSession()
+	if err != nil {
+		return wrapError("starting new mongo session", err)
+	}
+	defer session.EndSession(ctx)
+
+	transactionOptions := options.Transaction().
+		SetReadConcern(readconcern.Snapshot()).
+		SetWriteConcern(writeconcern.Majority())
+
+	_, err = session.WithTransaction(
+		ctx,
+		func(sc mongo.SessionContext) (interface{}, error) {
+			if err := er.fenceOutboxLease(sc, leaseTerm); err != nil {
+				return nil, err
+			}
+
+			return nil, er.updatePendingOutboxEvent(sc, operation, eventID, update)
+		},
+		transactionOptions,
+	)
+	if err != nil {
+		return wrapError(operation, err)
+	}
+
+	return nil
+}
+
+// fenceOutboxLease makes sure the lease is still held in the term. It writes to the lease, so the transaction
+// conflicts with another relay taking the lease over meanwhile.
+func (er *ExploreRepository) fenceOutboxLease(sc mongo.SessionContext, leaseTerm int64) error {
+	leaseFilters := bson.D{
+		{
+			Key: "_id", Value: outboxLeaseID,
+		},
+		{
+			Key: "term", Value: leaseTerm,
+		},
+	}
+
+	recordWrite := bson.D{
+		{
+			Key: "$inc",
+			Value: bson.D{
+				{
+					Key: "fencedWrites", Value: 1,
+				},
+			},
+		},
+	}
+
+	result, err := er.outboxLeases.UpdateOne(sc, leaseFilters, recordWrite)
+	if err != nil {
+		return wrapError("fencing outbox lease", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("outbox lease was taken over: %w", ErrConflict)
+	}
+
+	return nil
+}
+
+func (er *ExploreRepository) updatePendingOutboxEvent(
+	ctx context.Context,
+	operation, eventID string,
+	update bson.D,
+) error {
+	pendingFilters := bson.D{
+		{
+			Key: "_id", Value: eventID,
+		},
+		{
+			Key: "publishedAt", Value: bson.D{
+				{
+					Key: "$exists", Value: false,
+				},
+			},
+		},
+	}
+
+	result, err := er.outbox.UpdateOne(ctx, pendingFilters, update)
+	if err != nil {
+		return wrapError(operation, err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("outbox event is not pending: %w", ErrNotFound)
+	}
+
+	return nil
+}
+
+// AcquireOutboxLease takes the lease of publishing the outbox events for the duration, or extends it if the owner
+// already holds it, and reports whether the owner holds it now along with the term of the lease. Every relay taking
+// the lease over starts the next term, and the events are only marked in the current one, so only one relay
+// publishes the events at a time and the events of every pair are published in order.
+func (er *ExploreRepository) AcquireOutboxLease(
+	ctx context.Context,
+	owner string,
+	duration time.Duration,
+) (int64, bool, error) {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	leaseFilters := bson.D{
+		{
+			Key: "_id", Value: outboxLeaseID,
+		},
+		{
+			Key: "$or", Value: bson.A{
+				bson.D{
+					{
+						Key: "owner", Value: owner,
+					},
+				},
+				bson.D{
+					{
+						Key: "heldUntil", Value: bson.D{
+							{
+								Key: "$lte", Value: now,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// the term is kept while the owner extends the lease and moves on once another owner takes it
+	nextTerm := bson.D{
+		{
+			Key: "$cond", Value: bson.A{
+				bson.D{
+					{
+						Key: "$eq", Value: bson.A{"$owner", owner},
+					},
+				},
+				"$term",
+				bson.D{
+					{
+						Key: "$add", Value: bson.A{
+							bson.D{
+								{
+									Key: "$ifNull", Value: bson.A{"$term", 0},
+								},
+							},
+							1,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	takeLease := mongo.Pipeline{
+		{
+			{
+				Key: "$set",
+				Value: bson.D{
+					{
+						Key: "term", Value: nextTerm,
+					},
+					{
+						Key: "owner", Value: owner,
+					},
+					{
+						Key: "heldUntil", Value: now.Add(duration),
+					},
+				},
+			},
+		},
+	}
+
+	takeOptions := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var lease struct {
+		Term int64 `bson:"term"`
+	}
+
+	// the lease held by another relay does not match the filters, so the upsert collides with it
+	err := er.outboxLeases.FindOneAndUpdate(ctx, leaseFilters, takeLease, takeOptions).Decode(&lease)
+	if mongo.IsDuplicateKeyError(err) {
+		return 0, false, nil
+	}
+
+	if err != nil {
+		return 0, false, wrapError("acquiring outbox lease", err)
+	}
+
+	return lease.Term, true, nil
+}
+
+// SaveDeadLetter stores the failed delivery, replacing the stored one with the same ID.
+func (er *ExploreRepository) SaveDeadLetter(ctx context.Context, deadLetter model.DeadLetter) error {
+	_, err := er.deadLetters.ReplaceOne(
+		ctx,
+		bson.D{{Key: "_id", Value: deadLetter.ID}},
+		deadLetter,
+		options.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		return wrapError("saving dead letter", err)
+	}
+
+	return nil
+}
+
+// GetDeadLetters returns the dead letters with the given IDs, or the given number of the oldest dead letters when
+// no IDs are given, ordered by their events, the oldest first.
+func (er *ExploreRepository) GetDeadLetters(
+	ctx context.Context,
+	deadLetterIDs []string,
+	limit int64,
+) ([]model.DeadLetter, error) {
+	filters := bson.D{}
+	findOptions := options.Find().SetSort(bson.D{
+		{
+			Key: "event.occurredAt", Value: 1,
+		},
+		{
+			Key: "event.sequence", Value: 1,
+		},
+		{
+			Key: "_id", Value: 1,
+		},
+	})
+
+	if len(deadLetterIDs) > 0 {
+		filters = bson.D{
+			{
+				Key: "_id", Value: bson.D{
+					{
+						Key: "$in", Value: deadLetterIDs,
+					},
+				},
+			},
+		}
+	} else {
+		findOptions.SetLimit(limit)
+	}
+
+	cursor, err := er.deadLetters.Find(ctx, filters, findOptions)
+	if err != nil {
+		return nil, wrapError("finding dead letters", err)
+	}
+
+	deadLetters := make([]model.DeadLetter, 0)
+
+	if err = cursor.All(ctx, &deadLetters); err != nil {
+		return nil, wrapError("decoding dead letters", err)
+	}
+
+	return deadLetters, nil
+}
+
+// HasEarlierDeadLetters reports whether the pair has dead letters for the endpoint of the events before the sequence.
+func (er *ExploreRepository) HasEarlierDeadLetters(
+	ctx context.Context,
+	pairKey, endpoint string,
+	sequence int64,
+) (bool, error) {
+	filters := bson.D{
+		{
+			Key: "event.pairKey", Value: pairKey,
+		},
+		{
+			Key: "endpoint", Value: endpoint,
+		},
+		{
+			Key: "event.sequence", Value: bson.D{
+				{
+					Key: "$lt", Value: sequence,
+				},
+			},
+		},
+	}
+
+	count, err := er.deadLetters.CountDocuments(ctx, filters, options.Count().SetLimit(1))
+	if err != nil {
+		return false, wrapError("counting earlier dead letters", err)
+	}
+
+	return count > 0, nil
+}
+
+// RemoveDeadLetter forgets the dead letter once it is replayed successfully.
+func (er *ExploreRepository) RemoveDeadLetter(ctx context.Context, deadLetterID string) error {
+	result, err := er.deadLetters.DeleteOne(ctx, bson.D{{Key: "_id", Value: deadLetterID}})
+	if err != nil {
+		return wrapError("removing dead letter", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return fmt.Errorf("dead letter does not exist: %w", ErrNotFound)
+	}
+
+	return nil
+}
+
+// runTransaction runs the function in a transaction reading a snapshot of the data and written to the majority of
+// the replica set, recording the retries and the aborts of the transaction in the span.
+func (er *ExploreRepository) runTransaction(
+	ctx context.Context,
+	span trace.Span,
+	fn func(sc mongo.SessionContext) (interface{}, error),
+) (interface{}, error) {
+	session, err := er.mongoClient.StartSession()
+	if err != nil {
+		return nil, wrapError("starting new mongo session", err)
+	}
+	defer session.EndSession(ctx)
+
+	transactionOptions := options.Transaction().
+		SetReadConcern(readconcern.Snapshot()).
+		SetWriteConcern(writeconcern.Majority())
+
+	// WithTransaction commits the transaction and retries it as a whole on TransientTransactionError
+	// and the commit alone on UnknownTransactionCommitResult, aborting it on any other error.
+	attempts := 0
+
+	result, err := session.WithTransaction(
+		ctx,
+		func(sc mongo.SessionContext) (interface{}, error) {
+			if attempts++; attempts > 1 {
+				er.transactionObserver.TransactionRetried()
+				span.AddEvent("retrying transaction", trace.WithAttributes(attribute.Int("attempt", attempts)))
+			}
+
+			return fn(sc)
+		},
+		transactionOptions,
+	)
+	if err != nil {
+		// running out of super likes, having nothing to undo or unmatch or deciding about a blocked user is the
+		// expected outcome of the transaction rather than its failure
+		if !errors.Is(err, ErrLimitExceeded) && !errors.Is(err, ErrNotFound) && !errors.Is(err, ErrForbidden) {
+			er.transactionObserver.TransactionAborted()
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "transaction aborted")
+		}
+
+		return nil, wrapError("performing mongo transaction", err)
+	}
+
+	return result, nil
+}
+
+func (er *ExploreRepository) makeDecision(
+	sc mongo.SessionContext,
+	userID, recipientID string,
+	decision model.Decision,
+	superLikeLimit int64,
+) (model.DecisionResult, error) {
+	if err := er.checkNotBlocked(sc, userID, recipientID); err != nil {
+		return model.DecisionResult{}, err
+	}
+
+	userFilters := bson.D{
+		{
+			Key: "actorUserID", Value: userID,
+		},
+		{
+			Key: "recipientUserID", Value: recipientID,
+		},
+	}
+
+	recipientFilters := bson.D{
+		{
+			Key: "recipientUserID", Value: userID,
+		},
+		{
+			Key: "actorUserID", Value: recipientID,
+		},
+	}
+
+	// The recipient may not have made any decision on the user yet, in which case there is no
+	// document on their side and the user is treated as not liked.
+	recipientMatch := model.Match{ActorUserID: recipientID, RecipientUserID: userID}
+
+	recipientResult := er.collection.FindOne(sc, recipientFilters, options.FindOne())
+	if err := recipientResult.Decode(&recipientMatch); err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return model.DecisionResult{}, wrapError("finding user that recieved new decision", err)
+	}
+
+	// The previous decision of the user is kept in the history, so that the new one can be undone.
+	userMatch := model.Match{ActorUserID: userID, RecipientUserID: recipientID}
+
+	userResult := er.collection.FindOne(sc, userFilters, options.FindOne())
+	if err := userResult.Decode(&userMatch); err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return model.DecisionResult{}, wrapError("finding previous decision of the user", err)
+	}
+
+	mutualLikes := decision.Liked() && recipientMatch.Liked && !recipientMatch.Unmatched()
+	decidedAt := time.Now().UTC().Truncate(time.Millisecond)
+
+	var superLikeUsed bool
+
+	if decision == model.DecisionSuperLike {
+		var err error
+
+		if superLikeUsed, err = er.useSuperLike(sc, userID, &userMatch, decidedAt, superLikeLimit); err != nil {
+			return model.DecisionResult{}, err
+		}
+	}
+
+	userFields := bson.D{
+		{
+			Key:   "liked",
+			Value: decision.Liked(),
+		},
+		{
+			Key:   "decision",
+			Value: decision,
+		},
+		{
+			Key:   "matched",
+			Value: mutualLikes,
+		},
+		{
+			Key:   "updatedAt",
+			Value: decidedAt,
+		},
+	}
+
+	recipientFields := bson.D{
+		{
+			Key:   "matched",
+			Value: mutualLikes,
+		},
+	}
+
+	var unsetFields bson.D
+
+	if mutualLikes {
+		// A repeated like on an already matched user keeps the time the match was originally made.
+		matchedAt := decidedAt
+		if recipientMatch.Matched && !recipientMatch.MatchedAt.IsZero() {
+			matchedAt = recipientMatch.MatchedAt
+		}
+
+		userFields = append(userFields, bson.E{Key: "matchedAt", Value: matchedAt})
+		recipientFields = append(recipientFields, bson.E{Key: "matchedAt", Value: matchedAt})
+	} else {
+		unsetFields = bson.D{
+			{
+				Key:   "matchedAt",
+				Value: "",
+			},
+		}
+	}
+
+	updateUser := bson.D{
+		{
+			Key:   "$set",
+			Value: userFields,
+		},
+		{
+			// $min sets the field also on documents that were created before the user decided
+			Key: "$min",
+			Value: bson.D{
+				{
+					Key:   "createdAt",
+					Value: decidedAt,
+				},
+			},
+		},
+	}
+
+	updateRecipient := bson.D{
+		{
+			Key:   "$set",
+			Value: recipientFields,
+		},
+	}
+
+	if unsetFields != nil {
+		updateUser = append(updateUser, bson.E{Key: "$unset", Value: unsetFields})
+		updateRecipient = append(updateRecipient, bson.E{Key: "$unset", Value: unsetFields})
+	}
+
+	if _, err := er.collection.UpdateOne(sc, userFilters, updateUser, options.Update().SetUpsert(true)); err != nil {
+		return model.DecisionResult{}, wrapError("upserting user with new decision", err)
+	}
+
+	// The recipient side is upserted as well, even if the recipient has not decided yet, so that two
+	// concurrent decisions on the same pair always write the same documents. Such a write conflict
+	// aborts one of the transactions, which is then retried and sees the committed decision of the
+	// other one. A document created this way has no liked field, which reads as not liked.
+	if _, err := er.collection.UpdateOne(
+		sc,
+		recipientFilters,
+		updateRecipient,
+		options.Update().SetUpsert(true),
+	); err != nil {
+		return model.DecisionResult{}, wrapError("upserting recipient with new decision", err)
+	}
+
+	record := model.DecisionRecord{
+		ID:              uuid.NewString(),
+		ActorUserID:     userID,
+		RecipientUserID: recipientID,
+		Decision:        decision,
+		Previous:        previousMatch(&userMatch),
+		SuperLikeUsed:   superLikeUsed,
+		DecidedAt:       decidedAt,
+	}
+
+	if _, err := er.decisions.InsertOne(sc, record); err != nil {
+		return model.DecisionResult{}, wrapError("recording decision of the user", err)
+	}
+
+	userAfter, recipientAfter := userMatch, recipientMatch
+	userAfter.Liked, userAfter.Decision = decision.Liked(), decision
+	userAfter.Matched, recipientAfter.Matched = mutualLikes, mutualLikes
+
+	if err := er.recordEvents(sc, append(
+		matchEvents(&userMatch, &userAfter, decidedAt),
+		matchEvents(&recipientMatch, &recipientAfter, decidedAt)...,
+	)); err != nil {
+		return model.DecisionResult{}, err
+	}
+
+	if err := er.recordOutbox(
+		sc,
+		outboxEvents(&userMatch, &userAfter, model.DissolvedReasonPassed, decidedAt),
+	); err != nil {
+		return model.DecisionResult{}, err
+	}
+
+	return model.DecisionResult{
+		MutualLikes:  mutualLikes,
+		MatchCreated: mutualLikes && !recipientMatch.Matched,
+	}, nil
+}
+
+// useSuperLike counts the super like of the user on the day of the decision and reports whether it was counted, the
+// super like is not counted again if the user has already super liked the recipient. Concurrent super likes of the
+// same user write the same counter, so one of them is retried and sees the count including the other one.
+func (er *ExploreRepository) useSuperLike(
+	sc mongo.SessionContext,
+	userID string,
+	userMatch *model.Match,
+	decidedAt time.Time,
+	superLikeLimit int64,
+) (bool, error) {
+	if userMatch.SuperLiked() {
+		return false, nil
+	}
+
+	allowanceFilters := superLikesFilters(userID, decidedAt)
+
+	var allowance struct {
+		Count int64 `bson:"count"`
+	}
+
+	allowanceResult := er.superLikes.FindOne(sc, allowanceFilters, options.FindOne())
+	if err := allowanceResult.Decode(&allowance); err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return false, wrapError("finding super likes of the user", err)
+	}
+
+	if allowance.Count >= superLikeLimit {
+		return false, fmt.Errorf("all %d super likes of the day used: %w", superLikeLimit, ErrLimitExceeded)
+	}
+
+	updateAllowance := bson.D{
+		{
+			Key: "$inc",
+			Value: bson.D{
+				{
+					Key: "count", Value: 1,
+				},
+			},
+		},
+		{
+			// the counters of the past days are removed by the TTL index on this field
+			Key: "$setOnInsert",
+			Value: bson.D{
+				{
+					Key: "expiresAt", Value: decidedAt.Add(superLikeRetention),
+				},
+			},
+		},
+	}
+
+	if _, err := er.superLikes.UpdateOne(
+		sc,
+		allowanceFilters,
+		updateAllowance,
+		options.Update().SetUpsert(true),
+	); err != nil {
+		return false, wrapError("counting super like of the user", err)
+	}
+
+	return true, nil
+}
+
+func (er *ExploreRepository) undoDecision(
+	sc mongo.SessionContext,
+	userID string,
+	window time.Duration,
+) (model.DecisionRecord, error) {
+	var record model.DecisionRecord
+
+	latestOptions := options.FindOne().SetSort(bson.D{
+		{
+			Key: "decidedAt", Value: -1,
+		},
+	})
+
+	recordResult := er.decisions.FindOne(sc, bson.D{{Key: "actorUserID", Value: userID}}, latestOptions)
+	if err := recordResult.Decode(&record); err != nil {
+		return model.DecisionRecord{}, wrapError("finding latest decision of the user", err)
+	}
+
+	undoneAt := time.Now().UTC().Truncate(time.Millisecond)
+
+	if err := checkUndoable(&record, undoneAt, window); err != nil {
+		return model.DecisionRecord{}, err
+	}
+
+	if err := er.checkNotBlocked(sc, userID, record.RecipientUserID); err != nil {
+		return model.DecisionRecord{}, err
+	}
+
+	userFilters := bson.D{
+		{
+			Key: "actorUserID", Value: userID,
+		},
+		{
+			Key: "recipientUserID", Value: record.RecipientUserID,
+		},
+	}
+
+	recipientFilters := bson.D{
+		{
+			Key: "recipientUserID", Value: userID,
+		},
+		{
+			Key: "actorUserID", Value: record.RecipientUserID,
+		},
+	}
+
+	var userMatch model.Match
+
+	recipientMatch := model.Match{ActorUserID: record.RecipientUserID, RecipientUserID: userID}
+
+	if err := er.collection.FindOne(sc, userFilters, options.FindOne()).Decode(&userMatch); err != nil {
+		return model.DecisionRecord{}, wrapError("finding decision of the user", err)
+	}
+
+	// the decision is the latest one of the user, so the match can only differ if it was written concurrently
+	if !userMatch.UpdatedAt.Equal(record.DecidedAt) {
+		return model.DecisionRecord{}, fmt.Errorf("decision of the user changed while undoing it: %w", ErrConflict)
+	}
+
+	recipientResult := er.collection.FindOne(sc, recipientFilters, options.FindOne())
+	if err := recipientResult.Decode(&recipientMatch); err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return model.DecisionRecord{}, wrapError("finding decision of the recipient", err)
+	}
+
+	userRestored, recipientRestored := restoreMatches(&record, userMatch, recipientMatch)
+
+	if _, err := er.collection.UpdateOne(sc, userFilters, restoreUpdate(&userRestored, true)); err != nil {
+		return model.DecisionRecord{}, wrapError("restoring previous decision of the user", err)
+	}
+
+	if _, err := er.collection.UpdateOne(
+		sc,
+		recipientFilters,
+		restoreUpdate(&recipientRestored, false),
+		options.Update().SetUpsert(true),
+	); err != nil {
+		return model.DecisionRecord{}, wrapError("restoring match of the recipient", err)
+	}
+
+	if err := er.recordEvents(sc, append(
+		matchEvents(&userMatch, &userRestored, undoneAt),
+		matchEvents(&recipientMatch, &recipientRestored, undoneAt)...,
+	)); err != nil {
+		return model.DecisionRecord{}, err
+	}
+
+	if err := er.recordOutbox(
+		sc,
+		outboxEvents(&userMatch, &userRestored, model.DissolvedReasonUndone, undoneAt),
+	); err != nil {
+		return model.DecisionRecord{}, err
+	}
+
+	if record.SuperLikeUsed {
+		refund := bson.D{
+			{
+				Key: "$inc",
+				Value: bson.D{
+					{
+						Key: "count", Value: -1,
+					},
+				},
+			},
+		}
+
+		if _, err := er.superLikes.UpdateOne(sc, superLikesFilters(userID, record.DecidedAt), refund); err != nil {
+			return model.DecisionRecord{}, wrapError("giving back super like of the user", err)
+		}
+	}
+
+	record.UndoneAt = undoneAt
+
+	markUndone := bson.D{
+		{
+			Key: "$set",
+			Value: bson.D{
+				{
+					Key: "undoneAt", Value: undoneAt,
+				},
+			},
+		},
+	}
+
+	if _, err := er.decisions.UpdateByID(sc, record.ID, markUndone); err != nil {
+		return model.DecisionRecord{}, wrapError("marking decision of the user undone", err)
+	}
+
+	return record, nil
+}
+
+func (er *ExploreRepository) unmatch(
+	sc mongo.SessionContext,
+	userID, matchedUserID string,
+	reason model.UnmatchReason,
+) error {
+	userFilters := bson.D{
+		{
+			Key: "actorUserID", Value: userID,
+		},
+		{
+			Key: "recipientUserID", Value: matchedUserID,
+		},
+		{
+			Key: "matched", Value: true,
+		},
+	}
+
+	recipientFilters := bson.D{
+		{
+			Key: "actorUserID", Value: matchedUserID,
+		},
+		{
+			Key: "recipientUserID", Value: userID,
+		},
+	}
+
+	unmatchedAt := time.Now().UTC().Truncate(time.Millisecond)
+
+	update := unmatchUpdate(model.Unmatch{
+		UserID: userID,
+		Reason: reason,
+		At:     unmatchedAt,
+	})
+
+	// both matches are written, so concurrent unmatches of the pair conflict and the retried one finds the users
+	// no longer matched
+	result, err := er.collection.UpdateOne(sc, userFilters, update)
+	if err != nil {
+		return wrapError("unmatching user", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("users are not matched: %w", ErrNotFound)
+	}
+
+	if _, err = er.collection.UpdateOne(sc, recipientFilters, update); err != nil {
+		return wrapError("unmatching matched user", err)
+	}
+
+	if err = er.recordEvents(sc, dissolvedEvents(userID, matchedUserID, unmatchedAt)); err != nil {
+		return err
+	}
+
+	return er.recordOutbox(sc, []model.OutboxEvent{
+		dissolvedOutboxEvent(userID, matchedUserID, string(reason), unmatchedAt),
+	})
+}
+
+func (er *ExploreRepository) block(sc mongo.SessionContext, userID, blockedUserID string) error {
+	blockedAt := time.Now().UTC().Truncate(time.Millisecond)
+
+	blockFilters := bson.D{
+		{
+			Key: "blockerUserID", Value: userID,
+		},
+		{
+			Key: "blockedUserID", Value: blockedUserID,
+		},
+	}
+
+	insertBlock := bson.D{
+		{
+			Key: "$setOnInsert",
+			Value: bson.D{
+				{
+					Key: "createdAt", Value: blockedAt,
+				},
+			},
+		},
+	}
+
+	if _, err := er.blocks.UpdateOne(sc, blockFilters, insertBlock, options.Update().SetUpsert(true)); err != nil {
+		return wrapError("blocking user", err)
+	}
+
+	userFilters := bson.D{
+		{
+			Key: "actorUserID", Value: userID,
+		},
+		{
+			Key: "recipientUserID", Value: blockedUserID,
+		},
+	}
+
+	blockedFilters := bson.D{
+		{
+			Key: "actorUserID", Value: blockedUserID,
+		},
+		{
+			Key: "recipientUserID", Value: userID,
+		},
+	}
+
+	var userMatch model.Match
+
+	userResult := er.collection.FindOne(sc, userFilters, options.FindOne())
+	if err := userResult.Decode(&userMatch); err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return wrapError("finding match with blocked user", err)
+	}
+
+	update := bson.D{
+		{
+			Key: "$set",
+			Value: bson.D{
+				{
+					Key: "matched", Value: false,
+				},
+			},
+		},
+	}
+
+	if userMatch.Matched {
+		update = unmatchUpdate(model.Unmatch{
+			UserID: userID,
+			Reason: model.UnmatchReasonBlocked,
+			At:     blockedAt,
+		})
+	}
+
+	// Both matches are upserted like the decisions do, so a decision made concurrently on the pair conflicts with
+	// the block and is retried, finding the users blocked.
+	for _, filters := range []bson.D{userFilters, blockedFilters} {
+		if _, err := er.collection.UpdateOne(sc, filters, update, options.Update().SetUpsert(true)); err != nil {
+			return wrapError("dissolving match with blocked user", err)
+		}
+	}
+
+	if !userMatch.Matched {
+		return nil
+	}
+
+	if err := er.recordEvents(sc, dissolvedEvents(userID, blockedUserID, blockedAt)); err != nil {
+		return err
+	}
+
+	return er.recordOutbox(sc, []model.OutboxEvent{
+		dissolvedOutboxEvent(userID, blockedUserID, string(model.UnmatchReasonBlocked), blockedAt),
+	})
+}
+
+func (er *ExploreRepository) reportUser(
+	sc mongo.SessionContext,
+	report model.Report,
+	hideThreshold int64,
+) (model.ReportResult, error) {
+	report = openReport(report, time.Now().UTC().Truncate(time.Millisecond))
+
+	if err := er.block(sc, report.ReporterUserID, report.ReportedUserID); err != nil {
+		return model.ReportResult{}, err
+	}
+
+	if _, err := er.reports.InsertOne(sc, report); err != nil {
+		return model.ReportResult{}, wrapError("storing report", err)
+	}
+
+	hiddenChanged, err := er.updateReportedUser(sc, report.ReportedUserID, hideThreshold)
+	if err != nil {
+		return model.ReportResult{}, err
+	}
+
+	return model.ReportResult{Report: report, HiddenChanged: hiddenChanged}, nil
+}
+
+func (er *ExploreRepository) resolveReport(
+	sc mongo.SessionContext,
+	reportID string,
+	state model.ReportState,
+	note string,
+	hideThreshold int64,
+) (model.ReportResult, error) {
+	var report model.Report
+
+	if err := er.reports.FindOne(sc, bson.D{{Key: "_id", Value: reportID}}).Decode(&report); err != nil {
+		return model.ReportResult{}, wrapError("finding report", err)
+	}
+
+	if err := checkResolvable(&report); err != nil {
+		return model.ReportResult{}, err
+	}
+
+	report.State = state
+	report.ResolvedAt = time.Now().UTC().Truncate(time.Millisecond)
+	report.ResolutionNote = note
+
+	resolve := bson.D{
+		{
+			Key: "$set",
+			Value: bson.D{
+				{
+					Key: "state", Value: report.State,
+				},
+				{
+					Key: "resolvedAt", Value: report.ResolvedAt,
+				},
+				{
+					Key: "resolutionNote", Value: report.ResolutionNote,
+				},
+			},
+		},
+	}
+
+	// concurrent resolutions of the report write the same document, so the retried one finds the report resolved
+	if _, err := er.reports.UpdateByID(sc, report.ID, resolve); err != nil {
+		return model.ReportResult{}, wrapError("resolving report", err)
+	}
+
+	hiddenChanged, err := er.updateReportedUser(sc, report.ReportedUserID, hideThreshold)
+	if err != nil {
+		return model.ReportResult{}, err
+	}
+
+	return model.ReportResult{Report: report, HiddenChanged: hiddenChanged}, nil
+}
+
+// updateReportedUser counts the users with open reports about the user, stores whether they hide the user and
+// returns whether that changed. The document is written on every report and resolution, so the concurrent ones
+// conflict and the retried one counts the reports of the other one too.
+func (er *ExploreRepository) updateReportedUser(
+	sc mongo.SessionContext,
+	userID string,
+	hideThreshold int64,
+) (bool, error) {
+	openFilters := bson.D{
+		{
+			Key: "reportedUserID", Value: userID,
+		},
+		{
+			Key: "state", Value: model.ReportStateOpen,
+		},
+	}
+
+	reporters, err := er.reports.Distinct(sc, "reporterUserID", openFilters)
+	if err != nil {
+		return false, wrapError("counting users that reported the user", err)
+	}
+
+	hidden := shadowHidden(int64(len(reporters)), hideThreshold)
+
+	update := bson.D{
+		{
+			Key: "$set",
+			Value: bson.D{
+				{
+					Key: "openReporters", Value: len(reporters),
+				},
+				{
+					Key: "hidden", Value: hidden,
+				},
+			},
+		},
+	}
+
+	updateOptions := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.Before).
+		SetProjection(bson.D{{Key: "hidden", Value: 1}})
+
+	// the user reported for the first time has no document yet and was not hidden
+	var before struct {
+		Hidden bool `bson:"hidden"`
+	}
+
+	result := er.reportedUsers.FindOneAndUpdate(sc, bson.D{{Key: "_id", Value: userID}}, update, updateOptions)
+	if err = result.Decode(&before); err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return false, wrapError("updating reported user", err)
+	}
+
+	return before.Hidden != hidden, nil
+}
+
+// recordEvents inserts the events in the transaction of the change, so they are watched once the change commits.
+// The events about the users hidden by the reports are left out, as they would give the hiding away.
+func (er *ExploreRepository) recordEvents(sc mongo.SessionContext, events []model.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	otherUserIDs := make([]string, 0, len(events))
+	for _, event := range events {
+		otherUserIDs = append(otherUserIDs, event.OtherUserID)
+	}
+
+	hiddenFilters := bson.D{
+		{
+			Key: "_id", Value: bson.D{
+				{
+					Key: "$in", Value: otherUserIDs,
+				},
+			},
+		},
+		{
+			Key: "hidden", Value: true,
+		},
+	}
+
+	hidden, err := er.reportedUsers.Distinct(sc, "_id", hiddenFilters)
+	if err != nil {
+		return wrapError("finding users hidden by reports", err)
+	}
+
+	documents := make([]interface{}, 0, len(events))
+
+	for _, event := range events {
+		if !slices.Contains(hidden, interface{}(event.OtherUserID)) {
+			documents = append(documents, event)
+		}
+	}
+
+	if len(documents) == 0 {
+		return nil
+	}
+
+	if _, err = er.events.InsertMany(sc, documents); err != nil {
+		return wrapError("recording events", err)
+	}
+
+	return nil
+}
+
+// recordOutbox numbers the outbox events of the pair and inserts them in the transaction of the change. The counter
+// of the pair is written by every transaction recording its events, so concurrent ones conflict and the retried one
+// numbers its events after the events of the other.
+func (er *ExploreRepository) recordOutbox(sc mongo.SessionContext, events []model.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	increment := bson.D{
+		{
+			Key: "$inc",
+			Value: bson.D{
+				{
+					Key: "sequence", Value: len(events),
+				},
+			},
+		},
+	}
+
+	var counter struct {
+		Sequence int64 `bson:"sequence"`
+	}
+
+	counterOptions := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	counterResult := er.outboxSequences.FindOneAndUpdate(
+		sc,
+		bson.D{{Key: "_id", Value: events[0].PairKey}},
+		increment,
+		counterOptions,
+	)
+	if err := counterResult.Decode(&counter); err != nil {
+		return wrapError("numbering outbox events", err)
+	}
+
+	sequenceOutboxEvents(events, counter.Sequence-int64(len(events)))
+
+	documents := make([]interface{}, 0, len(events))
+	for _, event := range events {
+		documents = append(documents, event)
+	}
+
+	if _, err := er.outbox.InsertMany(sc, documents); err != nil {
+		return wrapError("recording outbox events", err)
+	}
+
+	return nil
+}
+
+// checkNotBlocked returns ErrForbidden if either of the users blocked the other.
+func (er *ExploreRepository) checkNotBlocked(sc mongo.SessionContext, userID, otherUserID string) error {
+	blocksFilters := bson.D{
+		{
+			Key: "$or",
+			Value: bson.A{
+				bson.D{{Key: "blockerUserID", Value: userID}, {Key: "blockedUserID", Value: otherUserID}},
+				bson.D{{Key: "blockerUserID", Value: otherUserID}, {Key: "blockedUserID", Value: userID}},
+			},
+		},
+	}
+
+	count, err := er.blocks.CountDocuments(sc, blocksFilters, options.Count().SetLimit(1))
+	if err != nil {
+		return wrapError("finding blocks between users", err)
+	}
+
+	if count > 0 {
+		return fmt.Errorf("one of the users blocked the other: %w", ErrForbidden)
+	}
+
+	return nil
+}
+
+// unmatchUpdate returns the update dissolving the match, it is written to the matches of both users.
+func unmatchUpdate(unmatch model.Unmatch) bson.D {
+	return bson.D{
+		{
+			Key: "$set",
+			Value: bson.D{
+				{
+					Key: "matched", Value: false,
+				},
+				{
+					Key: "unmatch", Value: unmatch,
+				},
+			},
+		},
+		{
+			Key: "$unset",
+			Value: bson.D{
+				{
+					Key: "matchedAt", Value: "",
+				},
+			},
+		},
+	}
+}
+
+// restoreUpdate returns the update writing the restored match, the decision fields are written only to the side of
+// the actor whose decision is undone. The times that are not set are removed.
+func restoreUpdate(match *model.Match, decision bool) bson.D {
+	set := bson.D{
+		{
+			Key: "matched", Value: match.Matched,
+		},
+	}
+
+	times := []bson.E{
+		{
+			Key: "matchedAt", Value: match.MatchedAt,
+		},
+	}
+
+	if decision {
+		set = append(set,
+			bson.E{Key: "liked", Value: match.Liked},
+			bson.E{Key: "decision", Value: match.Decision},
+		)
+
+		times = append(times,
+			bson.E{Key: "createdAt", Value: match.CreatedAt},
+			bson.E{Key: "updatedAt", Value: match.UpdatedAt},
+		)
+	}
+
+	var unset bson.D
+
+	for _, field := range times {
+		if field.Value.(time.Time).IsZero() {
+			unset = append(unset, bson.E{Key: field.Key, Value: ""})
+		} else {
+			set = append(set, field)
+		}
+	}
+
+	update := bson.D{
+		{
+			Key: "$set", Value: set,
+		},
+	}
+
+	if unset != nil {
+		update = append(update, bson.E{Key: "$unset", Value: unset})
+	}
+
+	return update
+}
+
+func superLikesFilters(userID string, decidedAt time.Time) bson.D {
+	return bson.D{
+		{
+			Key: "actorUserID", Value: userID,
+		},
+		{
+			Key: "day", Value: decidedAt.Format(time.DateOnly),
+		},
+	}
 }
@@ -0,0 +1,183 @@
+package repository_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/PatrykPasterny/dating-engine/internal/repository"
+	"github.com/PatrykPasterny/dating-engine/internal/repository/repositorytest"
+)
+
+// TestExploreRepository runs against the MongoDB replica set given by the same DATABASE_URI and DATABASE_NAME
+// environment variables the integration tests use, in a collection created just for the test.
+func TestExploreRepository(t *testing.T) {
+	databaseURI, databaseName := os.Getenv("DATABASE_URI"), os.Getenv("DATABASE_NAME")
+	if databaseURI == "" || databaseName == "" {
+		t.Skip("DATABASE_URI and DATABASE_NAME are not set")
+	}
+
+	mongoClient, err := mongo.Connect(context.Background(), options.Client().ApplyURI(databaseURI))
+	if err != nil {
+		t.Fatalf("failed connecting to mongoDB instance: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err = mongoClient.Disconnect(context.Background()); err != nil {
+			t.Errorf("failed disconnecting from mongoDB instance: %v", err)
+		}
+	})
+
+	collection := mongoClient.Database(databaseName).Collection("matches_" + uuid.NewString())
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{
+					Key: "actorUserID", Value: 1,
+				},
+				{
+					Key: "recipientUserID", Value: 1,
+				},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+	}
+
+	if _, err = collection.Indexes().CreateMany(context.Background(), indexes); err != nil {
+		t.Fatalf("failed creating indexes: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err = collection.Drop(context.Background()); err != nil {
+			t.Errorf("failed dropping collection: %v", err)
+		}
+	})
+
+	superLikes := mongoClient.Database(databaseName).Collection("superLikes_" + uuid.NewString())
+
+	superLikesIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{
+				Key: "actorUserID", Value: 1,
+			},
+			{
+				Key: "day", Value: 1,
+			},
+		},
+		Options: options.Index().SetUnique(true),
+	}
+
+	if _, err = superLikes.Indexes().CreateOne(context.Background(), superLikesIndex); err != nil {
+		t.Fatalf("failed creating super likes index: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err = superLikes.Drop(context.Background()); err != nil {
+			t.Errorf("failed dropping super likes collection: %v", err)
+		}
+	})
+
+	decisions := mongoClient.Database(databaseName).Collection("decisions_" + uuid.NewString())
+
+	decisionsIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{
+				Key: "actorUserID", Value: 1,
+			},
+			{
+				Key: "decidedAt", Value: -1,
+			},
+		},
+	}
+
+	if _, err = decisions.Indexes().CreateOne(context.Background(), decisionsIndex); err != nil {
+		t.Fatalf("failed creating decisions index: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err = decisions.Drop(context.Background()); err != nil {
+			t.Errorf("failed dropping decisions collection: %v", err)
+		}
+	})
+
+	blocks := mongoClient.Database(databaseName).Collection("blocks_" + uuid.NewString())
+
+	blocksIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{
+				Key: "blockerUserID", Value: 1,
+			},
+			{
+				Key: "blockedUserID", Value: 1,
+			},
+		},
+		Options: options.Index().SetUnique(true),
+	}
+
+	if _, err = blocks.Indexes().CreateOne(context.Background(), blocksIndex); err != nil {
+		t.Fatalf("failed creating blocks index: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err = blocks.Drop(context.Background()); err != nil {
+			t.Errorf("failed dropping blocks collection: %v", err)
+		}
+	})
+
+	reports := mongoClient.Database(databaseName).Collection("reports_" + uuid.NewString())
+	reportedUsers := mongoClient.Database(databaseName).Collection("reportedUsers_" + uuid.NewString())
+	events := mongoClient.Database(databaseName).Collection("events_" + uuid.NewString())
+	outbox := mongoClient.Database(databaseName).Collection("outbox_" + uuid.NewString())
+	outboxSequences := mongoClient.Database(databaseName).Collection("outboxSequences_" + uuid.NewString())
+	outboxLeases := mongoClient.Database(databaseName).Collection("outboxLeases_" + uuid.NewString())
+	deadLetters := mongoClient.Database(databaseName).Collection("deadLetters_" + uuid.NewString())
+
+	t.Cleanup(func() {
+		if err = reports.Drop(context.Background()); err != nil {
+			t.Errorf("failed dropping reports collection: %v", err)
+		}
+
+		if err = reportedUsers.Drop(context.Background()); err != nil {
+			t.Errorf("failed dropping reported users collection: %v", err)
+		}
+
+		if err = events.Drop(context.Background()); err != nil {
+			t.Errorf("failed dropping events collection: %v", err)
+		}
+
+		for _, collection := range []*mongo.Collection{outbox, outboxSequences, outboxLeases} {
+			if err = collection.Drop(context.Background()); err != nil {
+				t.Errorf("failed dropping outbox collection: %v", err)
+			}
+		}
+
+		if err = deadLetters.Drop(context.Background()); err != nil {
+			t.Errorf("failed dropping dead letters collection: %v", err)
+		}
+	})
+
+	suite.Run(t, &repositorytest.ConformanceSuite{
+		NewRepository: func() repositorytest.Repository {
+			return repository.NewExploreRepository(mongoClient, repository.Collections{
+				Matches:         collection,
+				SuperLikes:      superLikes,
+				Decisions:       decisions,
+				Blocks:          blocks,
+				Reports:         reports,
+				ReportedUsers:   reportedUsers,
+				Events:          events,
+				Outbox:          outbox,
+				OutboxSequences: outboxSequences,
+				OutboxLeases:    outboxLeases,
+				DeadLetters:     deadLetters,
+			})
+		},
+	})
+}
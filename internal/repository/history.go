@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+)
+
+// previousMatch returns the match to keep in the history before the actor decides again, it is nil if the actor has
+// not decided about the recipient yet.
+func previousMatch(userMatch *model.Match) *model.Match {
+	if !userMatch.Liked && userMatch.UpdatedAt.IsZero() {
+		return nil
+	}
+
+	previous := *userMatch
+
+	return &previous
+}
+
+// checkUndoable returns ErrNotFound unless the latest decision of the actor can still be undone. Only the latest
+// decision can be, so the decisions are never undone out of order.
+func checkUndoable(record *model.DecisionRecord, now time.Time, window time.Duration) error {
+	if !record.UndoneAt.IsZero() {
+		return fmt.Errorf("latest decision of the user is already undone: %w", ErrNotFound)
+	}
+
+	// the decision made at the very moment the window ends is out of it, so a zero window disables undoing
+	if !record.DecidedAt.After(now.Add(-window)) {
+		return fmt.Errorf("latest decision of the user was made more than %s ago: %w", window, ErrNotFound)
+	}
+
+	return nil
+}
+
+// restoreMatches returns the matches of the actor and the recipient as they were before the recorded decision. The
+// recipient may have decided since, so whether they are matched is worked out again from the current decision of
+// the recipient rather than restored. A dissolved match stays dissolved.
+func restoreMatches(record *model.DecisionRecord, userMatch, recipientMatch model.Match) (model.Match, model.Match) {
+	restored := model.Match{
+		ActorUserID:     record.ActorUserID,
+		RecipientUserID: record.RecipientUserID,
+	}
+
+	if record.Previous != nil {
+		restored = *record.Previous
+	}
+
+	restored.Unmatch = userMatch.Unmatch
+
+	restored.Matched = restored.Liked && recipientMatch.Liked && !restored.Unmatched()
+	recipientMatch.Matched = restored.Matched
+
+	if !restored.Matched {
+		restored.MatchedAt = time.Time{}
+	} else if restored.MatchedAt.IsZero() {
+		restored.MatchedAt = recipientMatch.MatchedAt
+	}
+
+	recipientMatch.MatchedAt = restored.MatchedAt
+
+	return restored, recipientMatch
+}
@@ -0,0 +1,926 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+	"github.com/PatrykPasterny/dating-engine/internal/pagination"
+)
+
+type matchKey struct {
+	actorUserID     string
+	recipientUserID string
+}
+
+// memoryEventRetention is how many of the latest events the repository keeps for the subscriptions to resume from.
+const memoryEventRetention = 1024
+
+type superLikeKey struct {
+	actorUserID string
+	day         string
+}
+
+// MemoryRepository keeps the matches in memory with the same semantics as ExploreRepository. It is meant for tests
+// and local development, as the matches are lost once the process stops.
+type MemoryRepository struct {
+	mu         sync.RWMutex
+	matches    map[matchKey]*model.Match
+	superLikes map[superLikeKey]int64
+	// history holds the decisions of every actor in the order they were made.
+	history map[string][]model.DecisionRecord
+	// blocks holds the time of every block, the actor of the key blocked the recipient.
+	blocks  map[matchKey]time.Time
+	reports map[string]*model.Report
+	// hidden holds the users hidden by the reports.
+	hidden map[string]bool
+	events *EventBus
+	// outbox holds the outbox events not published yet, in the order they were written.
+	outbox          []model.OutboxEvent
+	outboxSequences map[string]int64
+	outboxLease     struct {
+		owner     string
+		heldUntil time.Time
+		term      int64
+	}
+	deadLetters map[string]model.DeadLetter
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		matches:         make(map[matchKey]*model.Match),
+		superLikes:      make(map[superLikeKey]int64),
+		history:         make(map[string][]model.DecisionRecord),
+		blocks:          make(map[matchKey]time.Time),
+		reports:         make(map[string]*model.Report),
+		hidden:          make(map[string]bool),
+		events:          NewEventBus(memoryEventRetention),
+		outboxSequences: make(map[string]int64),
+		deadLetters:     make(map[string]model.DeadLetter),
+	}
+}
+
+func (mr *MemoryRepository) GetLikedUser(
+	ctx context.Context,
+	userID string,
+	page pagination.Page,
+) ([]model.Match, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, wrapError("finding users that liked the user", err)
+	}
+
+	mr.mu.RLock()
+	defer mr.mu.RUnlock()
+
+	return mr.findLikers(userID, page, func(match *model.Match) bool {
+		return true
+	}), nil
+}
+
+func (mr *MemoryRepository) GetNewLikedUser(
+	ctx context.Context,
+	userID string,
+	page pagination.Page,
+) ([]model.Match, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, wrapError("finding new users that liked the user", err)
+	}
+
+	mr.mu.RLock()
+	defer mr.mu.RUnlock()
+
+	return mr.findLikers(userID, page, func(match *model.Match) bool {
+		return !match.Matched
+	}), nil
+}
+
+func (mr *MemoryRepository) CountLikedUser(ctx context.Context, userID string) (uint64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, wrapError("counting users that liked the user", err)
+	}
+
+	mr.mu.RLock()
+	defer mr.mu.RUnlock()
+
+	var count uint64
+
+	for _, match := range mr.matches {
+		if match.RecipientUserID == userID && match.Liked && !match.Unmatched() && !mr.excluded(userID, match) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (mr *MemoryRepository) MakeDecision(
+	ctx context.Context,
+	userID, recipientID string,
+	decision model.Decision,
+	superLikeLimit int64,
+) (model.DecisionResult, error) {
+	if err := ctx.Err(); err != nil {
+		return model.DecisionResult{}, wrapError("making decision", err)
+	}
+
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	if mr.blockedEachOther(userID, recipientID) {
+		return model.DecisionResult{}, fmt.Errorf("one of the users blocked the other: %w", ErrForbidden)
+	}
+
+	// Mongo stores times with millisecond precision, so they are truncated to behave the same way.
+	decidedAt := time.Now().UTC().Truncate(time.Millisecond)
+
+	var superLikeUsed bool
+
+	if decision == model.DecisionSuperLike {
+		var err error
+
+		if superLikeUsed, err = mr.useSuperLike(userID, recipientID, decidedAt, superLikeLimit); err != nil {
+			return model.DecisionResult{}, wrapError("making decision", err)
+		}
+	}
+
+	userMatch := mr.getOrCreate(userID, recipientID)
+	recipientMatch := mr.getOrCreate(recipientID, userID)
+	userBefore, recipientBefore := *userMatch, *recipientMatch
+
+	mr.history[userID] = append(mr.history[userID], model.DecisionRecord{
+		ID:              uuid.NewString(),
+		ActorUserID:     userID,
+		RecipientUserID: recipientID,
+		Decision:        decision,
+		Previous:        previousMatch(userMatch),
+		SuperLikeUsed:   superLikeUsed,
+		DecidedAt:       decidedAt,
+	})
+
+	mutualLikes := decision.Liked() && recipientMatch.Liked && !recipientMatch.Unmatched()
+	matchCreated := mutualLikes && !recipientMatch.Matched
+
+	if mutualLikes {
+		// A repeated like on an already matched user keeps the time the match was originally made.
+		if !recipientMatch.Matched || recipientMatch.MatchedAt.IsZero() {
+			recipientMatch.MatchedAt = decidedAt
+		}
+
+		userMatch.MatchedAt = recipientMatch.MatchedAt
+	} else {
+		userMatch.MatchedAt = time.Time{}
+		recipientMatch.MatchedAt = time.Time{}
+	}
+
+	userMatch.Liked = decision.Liked()
+	userMatch.Decision = decision
+	userMatch.Matched = mutualLikes
+	userMatch.UpdatedAt = decidedAt
+	recipientMatch.Matched = mutualLikes
+
+	if userMatch.CreatedAt.IsZero() {
+		userMatch.CreatedAt = decidedAt
+	}
+
+	mr.publish(append(
+		matchEvents(&userBefore, userMatch, decidedAt),
+		matchEvents(&recipientBefore, recipientMatch, decidedAt)...,
+	))
+	mr.recordOutbox(outboxEvents(&userBefore, userMatch, model.DissolvedReasonPassed, decidedAt))
+
+	return model.DecisionResult{
+		MutualLikes:  mutualLikes,
+		MatchCreated: matchCreated,
+	}, nil
+}
+
+// UndoDecision reverts the latest decision of the user with the same semantics as ExploreRepository.UndoDecision.
+func (mr *MemoryRepository) UndoDecision(
+	ctx context.Context,
+	userID string,
+	window time.Duration,
+) (model.DecisionRecord, error) {
+	if err := ctx.Err(); err != nil {
+		return model.DecisionRecord{}, wrapError("undoing decision", err)
+	}
+
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	history := mr.history[userID]
+	if len(history) == 0 {
+		return model.DecisionRecord{}, fmt.Errorf("user has not decided yet: %w", ErrNotFound)
+	}
+
+	record := &history[len(history)-1]
+	undoneAt := time.Now().UTC().Truncate(time.Millisecond)
+
+	if err := checkUndoable(record, undoneAt, window); err != nil {
+		return model.DecisionRecord{}, err
+	}
+
+	if mr.blockedEachOther(userID, record.RecipientUserID) {
+		return model.DecisionRecord{}, fmt.Errorf("one of the users blocked the other: %w", ErrForbidden)
+	}
+
+	userMatch := mr.getOrCreate(userID, record.RecipientUserID)
+	recipientMatch := mr.getOrCreate(record.RecipientUserID, userID)
+	userBefore, recipientBefore := *userMatch, *recipientMatch
+
+	*userMatch, *recipientMatch = restoreMatches(record, *userMatch, *recipientMatch)
+
+	mr.publish(append(
+		matchEvents(&userBefore, userMatch, undoneAt),
+		matchEvents(&recipientBefore, recipientMatch, undoneAt)...,
+	))
+	mr.recordOutbox(outboxEvents(&userBefore, userMatch, model.DissolvedReasonUndone, undoneAt))
+
+	if record.SuperLikeUsed {
+		mr.superLikes[superLikeKeyOf(userID, record.DecidedAt)]--
+	}
+
+	record.UndoneAt = undoneAt
+
+	return *record, nil
+}
+
+// Unmatch dissolves the match of the users with the same semantics as ExploreRepository.Unmatch.
+func (mr *MemoryRepository) Unmatch(
+	ctx context.Context,
+	userID, matchedUserID string,
+	reason model.UnmatchReason,
+) error {
+	if err := ctx.Err(); err != nil {
+		return wrapError("unmatching users", err)
+	}
+
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	userMatch, ok := mr.matches[matchKey{actorUserID: userID, recipientUserID: matchedUserID}]
+	if !ok || !userMatch.Matched {
+		return fmt.Errorf("users are not matched: %w", ErrNotFound)
+	}
+
+	recipientMatch := mr.matches[matchKey{actorUserID: matchedUserID, recipientUserID: userID}]
+
+	unmatch := model.Unmatch{
+		UserID: userID,
+		Reason: reason,
+		At:     time.Now().UTC().Truncate(time.Millisecond),
+	}
+
+	for _, match := range []*model.Match{userMatch, recipientMatch} {
+		match.Matched = false
+		match.MatchedAt = time.Time{}
+		match.Unmatch = &unmatch
+	}
+
+	mr.publish(dissolvedEvents(userID, matchedUserID, unmatch.At))
+	mr.recordOutbox([]model.OutboxEvent{dissolvedOutboxEvent(userID, matchedUserID, string(reason), unmatch.At)})
+
+	return nil
+}
+
+// BlockUser blocks the user with the same semantics as ExploreRepository.BlockUser.
+func (mr *MemoryRepository) BlockUser(ctx context.Context, userID, blockedUserID string) error {
+	if err := ctx.Err(); err != nil {
+		return wrapError("blocking user", err)
+	}
+
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	mr.block(userID, blockedUserID)
+
+	return nil
+}
+
+func (mr *MemoryRepository) block(userID, blockedUserID string) {
+	blockedAt := time.Now().UTC().Truncate(time.Millisecond)
+	key := matchKey{actorUserID: userID, recipientUserID: blockedUserID}
+
+	if _, ok := mr.blocks[key]; !ok {
+		mr.blocks[key] = blockedAt
+	}
+
+	userMatch, ok := mr.matches[key]
+	if !ok || !userMatch.Matched {
+		return
+	}
+
+	blockedMatch := mr.matches[matchKey{actorUserID: blockedUserID, recipientUserID: userID}]
+
+	unmatch := model.Unmatch{
+		UserID: userID,
+		Reason: model.UnmatchReasonBlocked,
+		At:     blockedAt,
+	}
+
+	for _, match := range []*model.Match{userMatch, blockedMatch} {
+		match.Matched = false
+		match.MatchedAt = time.Time{}
+		match.Unmatch = &unmatch
+	}
+
+	mr.publish(dissolvedEvents(userID, blockedUserID, blockedAt))
+	mr.recordOutbox([]model.OutboxEvent{
+		dissolvedOutboxEvent(userID, blockedUserID, string(model.UnmatchReasonBlocked), blockedAt),
+	})
+}
+
+// UnblockUser lifts the block with the same semantics as ExploreRepository.UnblockUser.
+func (mr *MemoryRepository) UnblockUser(ctx context.Context, userID, blockedUserID string) error {
+	if err := ctx.Err(); err != nil {
+		return wrapError("unblocking user", err)
+	}
+
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	key := matchKey{actorUserID: userID, recipientUserID: blockedUserID}
+
+	if _, ok := mr.blocks[key]; !ok {
+		return fmt.Errorf("user is not blocked: %w", ErrNotFound)
+	}
+
+	delete(mr.blocks, key)
+
+	return nil
+}
+
+func (mr *MemoryRepository) GetBlockedUsers(ctx context.Context, userID string) ([]model.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, wrapError("finding users blocked by the user", err)
+	}
+
+	mr.mu.RLock()
+	defer mr.mu.RUnlock()
+
+	blocks := make([]model.Block, 0)
+
+	for key, blockedAt := range mr.blocks {
+		if key.actorUserID == userID {
+			blocks = append(blocks, model.Block{
+				BlockerUserID: userID,
+				BlockedUserID: key.recipientUserID,
+				CreatedAt:     blockedAt,
+			})
+		}
+	}
+
+	slices.SortFunc(blocks, func(a, b model.Block) int {
+		if result := b.CreatedAt.Compare(a.CreatedAt); result != 0 {
+			return result
+		}
+
+		return cmp.Compare(a.BlockedUserID, b.BlockedUserID)
+	})
+
+	return blocks, nil
+}
+
+// ReportUser stores the report with the same semantics as ExploreRepository.ReportUser.
+func (mr *MemoryRepository) ReportUser(
+	ctx context.Context,
+	report model.Report,
+	hideThreshold int64,
+) (model.ReportResult, error) {
+	if err := ctx.Err(); err != nil {
+		return model.ReportResult{}, wrapError("reporting user", err)
+	}
+
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	report = openReport(report, time.Now().UTC().Truncate(time.Millisecond))
+
+	mr.block(report.ReporterUserID, report.ReportedUserID)
+	mr.reports[report.ID] = &report
+	hiddenChanged := mr.updateHidden(report.ReportedUserID, hideThreshold)
+
+	return model.ReportResult{Report: report, HiddenChanged: hiddenChanged}, nil
+}
+
+func (mr *MemoryRepository) GetReports(
+	ctx context.Context,
+	state model.ReportState,
+	page pagination.ReportPage,
+) ([]model.Report, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, wrapError("finding reports", err)
+	}
+
+	mr.mu.RLock()
+	defer mr.mu.RUnlock()
+
+	reports := make([]model.Report, 0)
+
+	for _, report := range mr.reports {
+		if state != "" && report.State != state {
+			continue
+		}
+
+		if page.After != nil && !reportAfter(report, page.After) {
+			continue
+		}
+
+		reports = append(reports, *report)
+	}
+
+	slices.SortFunc(reports, func(a, b model.Report) int {
+		if result := a.CreatedAt.Compare(b.CreatedAt); result != 0 {
+			return result
+		}
+
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	if page.Limit > 0 && int64(len(reports)) > page.Limit {
+		reports = reports[:page.Limit]
+	}
+
+	return reports, nil
+}
+
+// ResolveReport resolves the report with the same semantics as ExploreRepository.ResolveReport.
+func (mr *MemoryRepository) ResolveReport(
+	ctx context.Context,
+	reportID string,
+	state model.ReportState,
+	note string,
+	hideThreshold int64,
+) (model.ReportResult, error) {
+	if err := ctx.Err(); err != nil {
+		return model.ReportResult{}, wrapError("resolving report", err)
+	}
+
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	report, ok := mr.reports[reportID]
+	if !ok {
+		return model.ReportResult{}, fmt.Errorf("report does not exist: %w", ErrNotFound)
+	}
+
+	if err := checkResolvable(report); err != nil {
+		return model.ReportResult{}, err
+	}
+
+	report.State = state
+	report.ResolvedAt = time.Now().UTC().Truncate(time.Millisecond)
+	report.ResolutionNote = note
+
+	hiddenChanged := mr.updateHidden(report.ReportedUserID, hideThreshold)
+
+	return model.ReportResult{Report: *report, HiddenChanged: hiddenChanged}, nil
+}
+
+// updateHidden stores whether the open reports about the user hide the user and returns whether that changed.
+func (mr *MemoryRepository) updateHidden(userID string, hideThreshold int64) bool {
+	reporters := make(map[string]struct{})
+
+	for _, report := range mr.reports {
+		if report.ReportedUserID == userID && report.State == model.ReportStateOpen {
+			reporters[report.ReporterUserID] = struct{}{}
+		}
+	}
+
+	hidden := shadowHidden(int64(len(reporters)), hideThreshold)
+	changed := mr.hidden[userID] != hidden
+	mr.hidden[userID] = hidden
+
+	return changed
+}
+
+// excluded reports whether the actor of the match is left out of the liker lists of the user, because either of
+// them blocked the other or the actor is hidden by the reports.
+func (mr *MemoryRepository) excluded(userID string, match *model.Match) bool {
+	return mr.blockedEachOther(userID, match.ActorUserID) || mr.hidden[match.ActorUserID]
+}
+
+// publish passes the events to the bus, leaving out those about the users hidden by the reports, which would
+// give the hiding away.
+func (mr *MemoryRepository) publish(events []model.Event) {
+	events = slices.DeleteFunc(events, func(event model.Event) bool {
+		return mr.hidden[event.OtherUserID]
+	})
+
+	if len(events) > 0 {
+		mr.events.Publish(events...)
+	}
+}
+
+// recordOutbox numbers the outbox events of the pair and keeps them until they are published.
+func (mr *MemoryRepository) recordOutbox(events []model.OutboxEvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	pairKey := events[0].PairKey
+
+	sequenceOutboxEvents(events, mr.outboxSequences[pairKey])
+	mr.outboxSequences[pairKey] += int64(len(events))
+	mr.outbox = append(mr.outbox, events...)
+}
+
+func (mr *MemoryRepository) blockedEachOther(userID, otherUserID string) bool {
+	_, blocked := mr.blocks[matchKey{actorUserID: userID, recipientUserID: otherUserID}]
+	_, blockedBy := mr.blocks[matchKey{actorUserID: otherUserID, recipientUserID: userID}]
+
+	return blocked || blockedBy
+}
+
+func (mr *MemoryRepository) useSuperLike(
+	userID, recipientID string,
+	decidedAt time.Time,
+	superLikeLimit int64,
+) (bool, error) {
+	if match, ok := mr.matches[matchKey{actorUserID: userID, recipientUserID: recipientID}]; ok && match.SuperLiked() {
+		return false, nil
+	}
+
+	key := superLikeKeyOf(userID, decidedAt)
+
+	if mr.superLikes[key] >= superLikeLimit {
+		return false, fmt.Errorf("all %d super likes of the day used: %w", superLikeLimit, ErrLimitExceeded)
+	}
+
+	mr.superLikes[key]++
+
+	return true, nil
+}
+
+func superLikeKeyOf(userID string, decidedAt time.Time) superLikeKey {
+	return superLikeKey{
+		actorUserID: userID,
+		day:         decidedAt.Format(time.DateOnly),
+	}
+}
+
+// getOrCreate returns the match of the actor with the recipient, creating the one without any decision if the
+// actor has not decided yet, just like ExploreRepository does.
+func (mr *MemoryRepository) getOrCreate(actorUserID, recipientUserID string) *model.Match {
+	key := matchKey{
+		actorUserID:     actorUserID,
+		recipientUserID: recipientUserID,
+	}
+
+	match, ok := mr.matches[key]
+	if !ok {
+		match = &model.Match{
+			ActorUserID:     actorUserID,
+			RecipientUserID: recipientUserID,
+		}
+
+		mr.matches[key] = match
+	}
+
+	return match
+}
+
+func (mr *MemoryRepository) findLikers(
+	userID string,
+	page pagination.Page,
+	include func(match *model.Match) bool,
+) []model.Match {
+	compare := func(position pagination.Position, match *model.Match) int {
+		if page.SuperLikesFirst && position.SuperLiked != match.SuperLiked() {
+			if position.SuperLiked {
+				return -1
+			}
+
+			return 1
+		}
+
+		result := cmp.Compare(position.ActorUserID, match.ActorUserID)
+
+		if page.Sort == pagination.SortByTime {
+			if timeResult := position.LikedAt.Compare(match.UpdatedAt); timeResult != 0 {
+				result = timeResult
+			}
+		}
+
+		if page.Order == pagination.Descending {
+			result = -result
+		}
+
+		return result
+	}
+
+	likers := make([]model.Match, 0)
+
+	for _, match := range mr.matches {
+		if match.RecipientUserID != userID || !match.Liked || match.Unmatched() || !include(match) {
+			continue
+		}
+
+		if mr.excluded(userID, match) {
+			continue
+		}
+
+		if page.After != nil && compare(*page.After, match) >= 0 {
+			continue
+		}
+
+		likers = append(likers, *match)
+	}
+
+	slices.SortFunc(likers, func(a, b model.Match) int {
+		return compare(positionOf(&a), &b)
+	})
+
+	if page.Limit > 0 && int64(len(likers)) > page.Limit {
+		likers = likers[:page.Limit]
+	}
+
+	return likers
+}
+
+func positionOf(match *model.Match) pagination.Position {
+	return pagination.Position{
+		SuperLiked:  match.SuperLiked(),
+		LikedAt:     match.UpdatedAt,
+		ActorUserID: match.ActorUserID,
+	}
+}
+
+// SubscribeEvents subscribes to the events of the user, see EventBus.SubscribeEvents.
+func (mr *MemoryRepository) SubscribeEvents(ctx context.Context, userID, resumeToken string) (Subscription, error) {
+	return mr.events.SubscribeEvents(ctx, userID, resumeToken)
+}
+
+// GetPendingOutboxEvents returns the outbox events not published yet with the same semantics as
+// ExploreRepository.GetPendingOutboxEvents.
+func (mr *MemoryRepository) GetPendingOutboxEvents(ctx context.Context, limit int64) ([]model.OutboxEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, wrapError("finding pending outbox events", err)
+	}
+
+	mr.mu.RLock()
+	defer mr.mu.RUnlock()
+
+	pairKeys := make(map[string]bool)
+	now := time.Now()
+
+	for i, due := 0, int64(0); i < len(mr.outbox) && due < limit; i++ {
+		if mr.outbox[i].NextAttemptAt.After(now) {
+			continue
+		}
+
+		pairKeys[mr.outbox[i].PairKey] = true
+		due++
+	}
+
+	events := make([]model.OutboxEvent, 0)
+
+	for _, event := range mr.outbox {
+		if pairKeys[event.PairKey] {
+			events = append(events, event)
+		}
+	}
+
+	slices.SortFunc(events, func(a, b model.OutboxEvent) int {
+		if result := cmp.Compare(a.PairKey, b.PairKey); result != 0 {
+			return result
+		}
+
+		return cmp.Compare(a.Sequence, b.Sequence)
+	})
+
+	return events, nil
+}
+
+// MarkOutboxEventPublished forgets the published outbox event while the lease is held in the given term, the memory
+// repository does not keep the published events.
+func (mr *MemoryRepository) MarkOutboxEventPublished(ctx context.Context, eventID string, leaseTerm int64) error {
+	if err := ctx.Err(); err != nil {
+		return wrapError("marking outbox event published", err)
+	}
+
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	if err := mr.fenceOutboxLease(leaseTerm); err != nil {
+		return err
+	}
+
+	i := slices.IndexFunc(mr.outbox, func(event model.OutboxEvent) bool {
+		return event.ID == eventID
+	})
+	if i < 0 {
+		return fmt.Errorf("outbox event is not pending: %w", ErrNotFound)
+	}
+
+	mr.outbox = slices.Delete(mr.outbox, i, i+1)
+
+	return nil
+}
+
+// RetryOutboxEvent records the failed attempt with the same semantics as ExploreRepository.RetryOutboxEvent.
+func (mr *MemoryRepository) RetryOutboxEvent(
+	ctx context.Context,
+	eventID string,
+	leaseTerm int64,
+	nextAttemptAt time.Time,
+) error {
+	if err := ctx.Err(); err != nil {
+		return wrapError("retrying outbox event", err)
+	}
+
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	if err := mr.fenceOutboxLease(leaseTerm); err != nil {
+		return err
+	}
+
+	event, err := mr.pendingOutboxEvent(eventID)
+	if err != nil {
+		return err
+	}
+
+	event.Attempts++
+	event.NextAttemptAt = nextAttemptAt
+
+	return nil
+}
+
+// MarkOutboxEventDelivered records the endpoints done with the event with the same semantics as
+// ExploreRepository.MarkOutboxEventDelivered.
+func (mr *MemoryRepository) MarkOutboxEventDelivered(ctx context.Context, eventID string, endpoints []string) error {
+	if err := ctx.Err(); err != nil {
+		return wrapError("marking outbox event delivered", err)
+	}
+
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	event, err := mr.pendingOutboxEvent(eventID)
+	if err != nil {
+		return err
+	}
+
+	// the events returned before share the slice, so it is copied rather than appended to
+	deliveredTo := slices.Clone(event.DeliveredTo)
+
+	for _, endpoint := range endpoints {
+		if !slices.Contains(deliveredTo, endpoint) {
+			deliveredTo = append(deliveredTo, endpoint)
+		}
+	}
+
+	event.DeliveredTo = deliveredTo
+
+	return nil
+}
+
+func (mr *MemoryRepository) fenceOutboxLease(leaseTerm int64) error {
+	if mr.outboxLease.term != leaseTerm {
+		return fmt.Errorf("outbox lease was taken over: %w", ErrConflict)
+	}
+
+	return nil
+}
+
+func (mr *MemoryRepository) pendingOutboxEvent(eventID string) (*model.OutboxEvent, error) {
+	i := slices.IndexFunc(mr.outbox, func(event model.OutboxEvent) bool {
+		return event.ID == eventID
+	})
+	if i < 0 {
+		return nil, fmt.Errorf("outbox event is not pending: %w", ErrNotFound)
+	}
+
+	return &mr.outbox[i], nil
+}
+
+// AcquireOutboxLease takes or extends the lease with the same semantics as ExploreRepository.AcquireOutboxLease.
+func (mr *MemoryRepository) AcquireOutboxLease(
+	ctx context.Context,
+	owner string,
+	duration time.Duration,
+) (int64, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, false, wrapError("acquiring outbox lease", err)
+	}
+
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	now := time.Now().UTC()
+
+	if !leaseAvailable(owner, mr.outboxLease.owner, mr.outboxLease.heldUntil, now) {
+		return 0, false, nil
+	}
+
+	if mr.outboxLease.owner != owner {
+		mr.outboxLease.term++
+	}
+
+	mr.outboxLease.owner = owner
+	mr.outboxLease.heldUntil = now.Add(duration)
+
+	return mr.outboxLease.term, true, nil
+}
+
+// SaveDeadLetter stores the failed delivery with the same semantics as ExploreRepository.SaveDeadLetter.
+func (mr *MemoryRepository) SaveDeadLetter(ctx context.Context, deadLetter model.DeadLetter) error {
+	if err := ctx.Err(); err != nil {
+		return wrapError("saving dead letter", err)
+	}
+
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	mr.deadLetters[deadLetter.ID] = deadLetter
+
+	return nil
+}
+
+// GetDeadLetters returns the dead letters with the same semantics as ExploreRepository.GetDeadLetters.
+func (mr *MemoryRepository) GetDeadLetters(
+	ctx context.Context,
+	deadLetterIDs []string,
+	limit int64,
+) ([]model.DeadLetter, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, wrapError("finding dead letters", err)
+	}
+
+	mr.mu.RLock()
+	defer mr.mu.RUnlock()
+
+	deadLetters := make([]model.DeadLetter, 0)
+
+	for _, deadLetter := range mr.deadLetters {
+		if len(deadLetterIDs) == 0 || slices.Contains(deadLetterIDs, deadLetter.ID) {
+			deadLetters = append(deadLetters, deadLetter)
+		}
+	}
+
+	slices.SortFunc(deadLetters, func(a, b model.DeadLetter) int {
+		if result := a.Event.OccurredAt.Compare(b.Event.OccurredAt); result != 0 {
+			return result
+		}
+
+		if result := cmp.Compare(a.Event.Sequence, b.Event.Sequence); result != 0 {
+			return result
+		}
+
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	if len(deadLetterIDs) == 0 && int64(len(deadLetters)) > limit {
+		deadLetters = deadLetters[:limit]
+	}
+
+	return deadLetters, nil
+}
+
+// HasEarlierDeadLetters looks for the dead letters with the same semantics as
+// ExploreRepository.HasEarlierDeadLetters.
+func (mr *MemoryRepository) HasEarlierDeadLetters(
+	ctx context.Context,
+	pairKey, endpoint string,
+	sequence int64,
+) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, wrapError("counting earlier dead letters", err)
+	}
+
+	mr.mu.RLock()
+	defer mr.mu.RUnlock()
+
+	for _, deadLetter := range mr.deadLetters {
+		if deadLetter.Event.PairKey == pairKey && deadLetter.Endpoint == endpoint &&
+			deadLetter.Event.Sequence < sequence {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// RemoveDeadLetter forgets the dead letter with the same semantics as ExploreRepository.RemoveDeadLetter.
+func (mr *MemoryRepository) RemoveDeadLetter(ctx context.Context, deadLetterID string) error {
+	if err := ctx.Err(); err != nil {
+		return wrapError("removing dead letter", err)
+	}
+
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	if _, ok := mr.deadLetters[deadLetterID]; !ok {
+		return fmt.Errorf("dead letter does not exist: %w", ErrNotFound)
+	}
+
+	delete(mr.deadLetters, deadLetterID)
+
+	return nil
+}
@@ -0,0 +1,18 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/PatrykPasterny/dating-engine/internal/repository"
+	"github.com/PatrykPasterny/dating-engine/internal/repository/repositorytest"
+)
+
+func TestMemoryRepository(t *testing.T) {
+	suite.Run(t, &repositorytest.ConformanceSuite{
+		NewRepository: func() repositorytest.Repository {
+			return repository.NewMemoryRepository()
+		},
+	})
+}
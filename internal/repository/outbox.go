@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+)
+
+// outboxEvents returns the outbox events of the change of the match made by its actor, a match the change dissolves
+// is dissolved for the reason.
+func outboxEvents(before, after *model.Match, dissolvedReason string, at time.Time) []model.OutboxEvent {
+	var events []model.OutboxEvent
+
+	if after.Liked && !before.Liked {
+		event := newOutboxEvent(model.OutboxEventLikeCreated, after.ActorUserID, after.RecipientUserID, at)
+		event.SuperLiked = after.SuperLiked()
+
+		events = append(events, event)
+	}
+
+	switch {
+	case after.Matched && !before.Matched:
+		events = append(events, newOutboxEvent(
+			model.OutboxEventMatchCreated,
+			after.ActorUserID,
+			after.RecipientUserID,
+			at,
+		))
+	case before.Matched && !after.Matched:
+		events = append(events, dissolvedOutboxEvent(after.ActorUserID, after.RecipientUserID, dissolvedReason, at))
+	}
+
+	return events
+}
+
+func dissolvedOutboxEvent(userID, matchedUserID, reason string, at time.Time) model.OutboxEvent {
+	event := newOutboxEvent(model.OutboxEventMatchDissolved, userID, matchedUserID, at)
+	event.Reason = reason
+
+	return event
+}
+
+func newOutboxEvent(eventType model.OutboxEventType, actorID, recipientID string, at time.Time) model.OutboxEvent {
+	return model.OutboxEvent{
+		ID:              uuid.NewString(),
+		Type:            eventType,
+		PairKey:         model.PairKey(actorID, recipientID),
+		ActorUserID:     actorID,
+		RecipientUserID: recipientID,
+		OccurredAt:      at,
+	}
+}
+
+// sequenceOutboxEvents numbers the events of the pair following the latest event of the pair written before.
+func sequenceOutboxEvents(events []model.OutboxEvent, latest int64) {
+	for i := range events {
+		events[i].Sequence = latest + int64(i) + 1
+	}
+}
+
+// leaseAvailable reports whether the owner can take the lease held by the holder until the time.
+func leaseAvailable(owner, holder string, heldUntil, now time.Time) bool {
+	return holder == owner || !now.Before(heldUntil)
+}
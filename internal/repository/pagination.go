@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+	"github.com/PatrykPasterny/dating-engine/internal/pagination"
+)
+
+// likedAtField holds the time of the like, as only liked matches are paginated.
+const likedAtField = "updatedAt"
+
+// pageSort returns the order the likers of the page are sorted in.
+func pageSort(page pagination.Page) bson.D {
+	direction := 1
+	if page.Order == pagination.Descending {
+		direction = -1
+	}
+
+	var sort bson.D
+
+	if page.Sort == pagination.SortByTime {
+		sort = append(sort, bson.E{Key: likedAtField, Value: direction})
+	}
+
+	return append(sort, bson.E{Key: "actorUserID", Value: direction})
+}
+
+// pageFilter returns the filter selecting likers placed after the page position, it returns false for the first
+// page. Matches created before the like time was stored have no time set, which Mongo sorts before any time.
+func pageFilter(page pagination.Page) (bson.E, bool) {
+	if page.After == nil {
+		return bson.E{}, false
+	}
+
+	comparison := "$gt"
+	if page.Order == pagination.Descending {
+		comparison = "$lt"
+	}
+
+	afterActor := bson.D{
+		{
+			Key: comparison, Value: page.After.ActorUserID,
+		},
+	}
+
+	if page.Sort == pagination.SortByActor {
+		return bson.E{
+			Key: "actorUserID", Value: afterActor,
+		}, true
+	}
+
+	var likedAt interface{}
+	if !page.After.LikedAt.IsZero() {
+		likedAt = page.After.LikedAt
+	}
+
+	conditions := bson.A{
+		bson.D{
+			{
+				Key: likedAtField, Value: likedAt,
+			},
+			{
+				Key: "actorUserID", Value: afterActor,
+			},
+		},
+	}
+
+	switch {
+	case likedAt == nil && page.Order == pagination.Ascending:
+		conditions = append(conditions, bson.D{
+			{
+				Key: likedAtField, Value: bson.D{
+					{
+						Key: "$ne", Value: nil,
+					},
+				},
+			},
+		})
+	case likedAt != nil && page.Order == pagination.Ascending:
+		conditions = append(conditions, bson.D{
+			{
+				Key: likedAtField, Value: bson.D{
+					{
+						Key: comparison, Value: likedAt,
+					},
+				},
+			},
+		})
+	case likedAt != nil && page.Order == pagination.Descending:
+		conditions = append(conditions,
+			bson.D{
+				{
+					Key: likedAtField, Value: bson.D{
+						{
+							Key: comparison, Value: likedAt,
+						},
+					},
+				},
+			},
+			bson.D{
+				{
+					Key: likedAtField, Value: nil,
+				},
+			},
+		)
+	}
+
+	return bson.E{
+		Key: "$or", Value: conditions,
+	}, true
+}
+
+// superLikedFilter selects either the super likes or all the other likes, including the ones stored before the
+// decisions were, which have no decision set.
+func superLikedFilter(superLiked bool) bson.E {
+	if superLiked {
+		return bson.E{
+			Key: "decision", Value: model.DecisionSuperLike,
+		}
+	}
+
+	return bson.E{
+		Key: "decision", Value: bson.D{
+			{
+				Key: "$ne", Value: model.DecisionSuperLike,
+			},
+		},
+	}
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+	"github.com/PatrykPasterny/dating-engine/internal/pagination"
+)
+
+// openReport returns the report as it is stored when it is made, waiting for the review.
+func openReport(report model.Report, createdAt time.Time) model.Report {
+	report.ID = uuid.NewString()
+	report.State = model.ReportStateOpen
+	report.CreatedAt = createdAt
+	report.ResolvedAt = time.Time{}
+	report.ResolutionNote = ""
+
+	return report
+}
+
+// checkResolvable returns ErrNotFound unless the report is still open, so every report is reviewed only once.
+func checkResolvable(report *model.Report) error {
+	if report.State != model.ReportStateOpen {
+		return fmt.Errorf("report is already %s: %w", report.State, ErrNotFound)
+	}
+
+	return nil
+}
+
+// shadowHidden reports whether the user reported by the number of users with open reports is hidden from the liker
+// lists of the others, a zero threshold never hides anyone. Counting the reporters rather than the reports keeps a
+// single user from hiding anyone by reporting them over and over.
+func shadowHidden(reporters, hideThreshold int64) bool {
+	return hideThreshold > 0 && reporters >= hideThreshold
+}
+
+// reportAfter reports whether the report comes after the position in the queue ordered by the time the reports were
+// made and their identifiers.
+func reportAfter(report *model.Report, position *pagination.ReportPosition) bool {
+	if result := report.CreatedAt.Compare(position.CreatedAt); result != 0 {
+		return result > 0
+	}
+
+	return report.ID > position.ReportID
+}
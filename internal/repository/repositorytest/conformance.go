@@ -0,0 +1,1508 @@
+// Package repositorytest holds the conformance suite every match repository implementation has to pass, so the
+// implementations can be used interchangeably.
+package repositorytest
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+	"github.com/PatrykPasterny/dating-engine/internal/pagination"
+	"github.com/PatrykPasterny/dating-engine/internal/repository"
+)
+
+const (
+	// superLikeLimit is the daily super like allowance of every user in the scenarios.
+	superLikeLimit = 3
+	// undoWindow is how long the decisions can be undone in the scenarios.
+	undoWindow = time.Minute
+	// hideThreshold is the number of users that have to report a user to hide them in the scenarios.
+	hideThreshold = 2
+	// eventTimeout is how long the scenarios wait for an event.
+	eventTimeout = 5 * time.Second
+)
+
+// Repository is the match repository under test.
+type Repository interface {
+	GetLikedUser(ctx context.Context, userID string, page pagination.Page) ([]model.Match, error)
+	GetNewLikedUser(ctx context.Context, userID string, page pagination.Page) ([]model.Match, error)
+	CountLikedUser(ctx context.Context, userID string) (uint64, error)
+	MakeDecision(
+		ctx context.Context,
+		userID, recipientID string,
+		decision model.Decision,
+		superLikeLimit int64,
+	) (model.DecisionResult, error)
+	UndoDecision(ctx context.Context, userID string, window time.Duration) (model.DecisionRecord, error)
+	Unmatch(ctx context.Context, userID, matchedUserID string, reason model.UnmatchReason) error
+	BlockUser(ctx context.Context, userID, blockedUserID string) error
+	UnblockUser(ctx context.Context, userID, blockedUserID string) error
+	GetBlockedUsers(ctx context.Context, userID string) ([]model.Block, error)
+	ReportUser(ctx context.Context, report model.Report, hideThreshold int64) (model.ReportResult, error)
+	GetReports(ctx context.Context, state model.ReportState, page pagination.ReportPage) ([]model.Report, error)
+	ResolveReport(
+		ctx context.Context,
+		reportID string,
+		state model.ReportState,
+		note string,
+		hideThreshold int64,
+	) (model.ReportResult, error)
+}
+
+// Watcher is implemented by the repositories whose events can be subscribed to, the event scenarios are skipped for
+// the other repositories.
+type Watcher interface {
+	SubscribeEvents(ctx context.Context, userID, resumeToken string) (repository.Subscription, error)
+}
+
+// OutboxStore is implemented by the repositories writing the outbox events, the outbox scenarios are skipped for the
+// other repositories.
+type OutboxStore interface {
+	GetPendingOutboxEvents(ctx context.Context, limit int64) ([]model.OutboxEvent, error)
+	MarkOutboxEventPublished(ctx context.Context, eventID string, leaseTerm int64) error
+	RetryOutboxEvent(ctx context.Context, eventID string, leaseTerm int64, nextAttemptAt time.Time) error
+	AcquireOutboxLease(ctx context.Context, owner string, duration time.Duration) (int64, bool, error)
+}
+
+// DeadLetterStore is implemented by the repositories keeping the failed webhook deliveries, the dead letter
+// scenarios are skipped for the other repositories.
+type DeadLetterStore interface {
+	MarkOutboxEventDelivered(ctx context.Context, eventID string, endpoints []string) error
+	SaveDeadLetter(ctx context.Context, deadLetter model.DeadLetter) error
+	GetDeadLetters(ctx context.Context, deadLetterIDs []string, limit int64) ([]model.DeadLetter, error)
+	HasEarlierDeadLetters(ctx context.Context, pairKey, endpoint string, sequence int64) (bool, error)
+	RemoveDeadLetter(ctx context.Context, deadLetterID string) error
+}
+
+// ConformanceSuite runs the same scenarios against any repository. Every scenario uses newly generated users, so
+// the repository may be shared between the tests.
+type ConformanceSuite struct {
+	suite.Suite
+	// NewRepository is called before every test.
+	NewRepository func() Repository
+	repository    Repository
+}
+
+func (s *ConformanceSuite) SetupTest() {
+	s.repository = s.NewRepository()
+}
+
+func (s *ConformanceSuite) TestFirstLikeIsListedForRecipient() {
+	actorID, recipientID := s.newUserID(), s.newUserID()
+
+	result, err := s.repository.MakeDecision(
+		context.Background(),
+		actorID,
+		recipientID,
+		model.DecisionLike,
+		superLikeLimit,
+	)
+	s.Require().NoError(err)
+	s.False(result.MutualLikes)
+
+	likers := s.likerIDs(s.repository.GetLikedUser, recipientID)
+	s.Equal([]string{actorID}, likers)
+
+	newLikers := s.likerIDs(s.repository.GetNewLikedUser, recipientID)
+	s.Equal([]string{actorID}, newLikers)
+
+	count, err := s.repository.CountLikedUser(context.Background(), recipientID)
+	s.Require().NoError(err)
+	s.Equal(uint64(1), count)
+
+	s.Empty(s.likerIDs(s.repository.GetLikedUser, actorID))
+}
+
+func (s *ConformanceSuite) TestFirstPassIsNotListedForRecipient() {
+	actorID, recipientID := s.newUserID(), s.newUserID()
+
+	result, err := s.repository.MakeDecision(
+		context.Background(),
+		actorID,
+		recipientID,
+		model.DecisionPass,
+		superLikeLimit,
+	)
+	s.Require().NoError(err)
+	s.False(result.MutualLikes)
+
+	s.Empty(s.likerIDs(s.repository.GetLikedUser, recipientID))
+	s.Empty(s.likerIDs(s.repository.GetNewLikedUser, recipientID))
+
+	count, err := s.repository.CountLikedUser(context.Background(), recipientID)
+	s.Require().NoError(err)
+	s.Zero(count)
+}
+
+func (s *ConformanceSuite) TestMutualLikesMatchBothUsers() {
+	firstID, secondID := s.newUserID(), s.newUserID()
+
+	result, err := s.repository.MakeDecision(
+		context.Background(),
+		firstID,
+		secondID,
+		model.DecisionLike,
+		superLikeLimit,
+	)
+	s.Require().NoError(err)
+	s.False(result.MutualLikes)
+
+	result, err = s.repository.MakeDecision(
+		context.Background(),
+		secondID,
+		firstID,
+		model.DecisionLike,
+		superLikeLimit,
+	)
+	s.Require().NoError(err)
+	s.True(result.MutualLikes)
+	s.True(result.MatchCreated)
+
+	for _, users := range [][2]string{{firstID, secondID}, {secondID, firstID}} {
+		likers := s.likers(s.repository.GetLikedUser, users[1])
+		s.Require().Len(likers, 1)
+		s.Equal(users[0], likers[0].ActorUserID)
+		s.True(likers[0].Liked)
+		s.True(likers[0].Matched)
+		s.False(likers[0].MatchedAt.IsZero())
+
+		s.Empty(s.likerIDs(s.repository.GetNewLikedUser, users[1]))
+	}
+
+	firstLiker := s.likers(s.repository.GetLikedUser, secondID)[0]
+	secondLiker := s.likers(s.repository.GetLikedUser, firstID)[0]
+	s.True(firstLiker.MatchedAt.Equal(secondLiker.MatchedAt))
+}
+
+func (s *ConformanceSuite) TestPassDissolvesMatch() {
+	firstID, secondID := s.newUserID(), s.newUserID()
+
+	s.decide(firstID, secondID, model.DecisionLike)
+	s.decide(secondID, firstID, model.DecisionLike)
+
+	result, err := s.repository.MakeDecision(
+		context.Background(),
+		secondID,
+		firstID,
+		model.DecisionPass,
+		superLikeLimit,
+	)
+	s.Require().NoError(err)
+	s.False(result.MutualLikes)
+
+	s.Empty(s.likerIDs(s.repository.GetLikedUser, firstID))
+
+	likers := s.likers(s.repository.GetNewLikedUser, secondID)
+	s.Require().Len(likers, 1)
+	s.Equal(firstID, likers[0].ActorUserID)
+	s.False(likers[0].Matched)
+	s.True(likers[0].MatchedAt.IsZero())
+
+	count, err := s.repository.CountLikedUser(context.Background(), secondID)
+	s.Require().NoError(err)
+	s.Equal(uint64(1), count)
+}
+
+func (s *ConformanceSuite) TestRepeatedLikeKeepsFirstDecisionAndMatchTime() {
+	firstID, secondID := s.newUserID(), s.newUserID()
+
+	s.decide(firstID, secondID, model.DecisionLike)
+	s.decide(secondID, firstID, model.DecisionLike)
+
+	before := s.likers(s.repository.GetLikedUser, secondID)[0]
+
+	time.Sleep(5 * time.Millisecond)
+
+	result, err := s.repository.MakeDecision(
+		context.Background(),
+		firstID,
+		secondID,
+		model.DecisionLike,
+		superLikeLimit,
+	)
+	s.Require().NoError(err)
+	s.True(result.MutualLikes)
+	s.False(result.MatchCreated)
+
+	after := s.likers(s.repository.GetLikedUser, secondID)[0]
+	s.True(before.CreatedAt.Equal(after.CreatedAt))
+	s.True(before.MatchedAt.Equal(after.MatchedAt))
+	s.True(after.UpdatedAt.After(before.UpdatedAt))
+}
+
+func (s *ConformanceSuite) TestPaginationReturnsAllLikersInOrder() {
+	const likersCount = 7
+
+	recipientID := s.newUserID()
+
+	for i := range likersCount {
+		actorID := s.newUserID()
+
+		s.decide(actorID, recipientID, model.DecisionLike)
+
+		// users that passed or matched must not be paginated over by the new likers list
+		switch i % 3 {
+		case 1:
+			s.decide(s.newUserID(), recipientID, model.DecisionPass)
+		case 2:
+			s.decide(recipientID, actorID, model.DecisionLike)
+		}
+	}
+
+	for _, sort := range []pagination.Sort{pagination.SortByActor, pagination.SortByTime} {
+		for _, order := range []pagination.Order{pagination.Ascending, pagination.Descending} {
+			page := pagination.Page{Sort: sort, Order: order, Limit: 2}
+
+			likers := s.allLikers(s.repository.GetLikedUser, recipientID, page)
+			s.Len(likers, likersCount)
+			s.requireSorted(likers, page)
+
+			page.Limit = 3
+
+			newLikers := s.allLikers(s.repository.GetNewLikedUser, recipientID, page)
+			s.Len(newLikers, likersCount-likersCount/3)
+			s.requireSorted(newLikers, page)
+		}
+	}
+}
+
+func (s *ConformanceSuite) TestSuperLikeIsListedAsLike() {
+	actorID, recipientID := s.newUserID(), s.newUserID()
+
+	s.decide(actorID, recipientID, model.DecisionSuperLike)
+
+	likers := s.likers(s.repository.GetNewLikedUser, recipientID)
+	s.Require().Len(likers, 1)
+	s.True(likers[0].Liked)
+	s.True(likers[0].SuperLiked())
+	s.Equal(model.DecisionSuperLike, likers[0].Decision)
+
+	count, err := s.repository.CountLikedUser(context.Background(), recipientID)
+	s.Require().NoError(err)
+	s.Equal(uint64(1), count)
+
+	result, err := s.repository.MakeDecision(
+		context.Background(),
+		recipientID,
+		actorID,
+		model.DecisionLike,
+		superLikeLimit,
+	)
+	s.Require().NoError(err)
+	s.True(result.MutualLikes)
+}
+
+func (s *ConformanceSuite) TestSuperLikesAreListedFirst() {
+	recipientID := s.newUserID()
+
+	for i := range 7 {
+		decision := model.DecisionLike
+		if i%3 == 0 {
+			decision = model.DecisionSuperLike
+		}
+
+		s.decide(s.newUserID(), recipientID, decision)
+	}
+
+	for _, sort := range []pagination.Sort{pagination.SortByActor, pagination.SortByTime} {
+		for _, order := range []pagination.Order{pagination.Ascending, pagination.Descending} {
+			page := pagination.Page{Sort: sort, Order: order, Limit: 2, SuperLikesFirst: true}
+
+			likers := s.allLikers(s.repository.GetNewLikedUser, recipientID, page)
+			s.Len(likers, 7)
+			s.requireSorted(likers, page)
+			s.True(likers[0].SuperLiked())
+		}
+	}
+}
+
+func (s *ConformanceSuite) TestSuperLikesAreLimitedPerDay() {
+	actorID := s.newUserID()
+	recipientIDs := make([]string, 0, superLikeLimit)
+
+	for range superLikeLimit {
+		recipientID := s.newUserID()
+		recipientIDs = append(recipientIDs, recipientID)
+
+		s.decide(actorID, recipientID, model.DecisionSuperLike)
+	}
+
+	// super liking the same user again does not use up another super like
+	s.decide(actorID, recipientIDs[0], model.DecisionSuperLike)
+
+	recipientID := s.newUserID()
+
+	_, err := s.repository.MakeDecision(
+		context.Background(),
+		actorID,
+		recipientID,
+		model.DecisionSuperLike,
+		superLikeLimit,
+	)
+	s.Require().ErrorIs(err, repository.ErrLimitExceeded)
+	s.Empty(s.likerIDs(s.repository.GetLikedUser, recipientID))
+
+	s.decide(actorID, recipientID, model.DecisionLike)
+	s.decide(s.newUserID(), recipientID, model.DecisionSuperLike)
+}
+
+func (s *ConformanceSuite) TestUndoRestoresPreviousDecision() {
+	actorID, recipientID := s.newUserID(), s.newUserID()
+
+	s.decide(actorID, recipientID, model.DecisionPass)
+	s.decide(actorID, recipientID, model.DecisionLike)
+
+	record, err := s.repository.UndoDecision(context.Background(), actorID, undoWindow)
+	s.Require().NoError(err)
+	s.Equal(recipientID, record.RecipientUserID)
+	s.Equal(model.DecisionLike, record.Decision)
+	s.Require().NotNil(record.Previous)
+	s.Equal(model.DecisionPass, record.Previous.Decision)
+	s.False(record.UndoneAt.IsZero())
+
+	s.Empty(s.likerIDs(s.repository.GetLikedUser, recipientID))
+
+	count, err := s.repository.CountLikedUser(context.Background(), recipientID)
+	s.Require().NoError(err)
+	s.Zero(count)
+}
+
+func (s *ConformanceSuite) TestUndoFirstDecisionForgetsIt() {
+	actorID, recipientID := s.newUserID(), s.newUserID()
+
+	s.decide(actorID, recipientID, model.DecisionLike)
+
+	record, err := s.repository.UndoDecision(context.Background(), actorID, undoWindow)
+	s.Require().NoError(err)
+	s.Nil(record.Previous)
+
+	s.Empty(s.likerIDs(s.repository.GetLikedUser, recipientID))
+
+	result, err := s.repository.MakeDecision(
+		context.Background(),
+		recipientID,
+		actorID,
+		model.DecisionLike,
+		superLikeLimit,
+	)
+	s.Require().NoError(err)
+	s.False(result.MutualLikes)
+}
+
+func (s *ConformanceSuite) TestUndoLikeDissolvesMatch() {
+	actorID, recipientID := s.newUserID(), s.newUserID()
+
+	s.decide(recipientID, actorID, model.DecisionLike)
+	s.decide(actorID, recipientID, model.DecisionLike)
+	s.Empty(s.likerIDs(s.repository.GetNewLikedUser, actorID))
+
+	_, err := s.repository.UndoDecision(context.Background(), actorID, undoWindow)
+	s.Require().NoError(err)
+
+	// the like of the recipient is left as it was, but no longer answered
+	s.Equal([]string{recipientID}, s.likerIDs(s.repository.GetNewLikedUser, actorID))
+	s.Empty(s.likerIDs(s.repository.GetLikedUser, recipientID))
+
+	likers := s.likers(s.repository.GetLikedUser, actorID)
+	s.Require().Len(likers, 1)
+	s.False(likers[0].Matched)
+	s.True(likers[0].MatchedAt.IsZero())
+}
+
+func (s *ConformanceSuite) TestUndoPassRestoresMatch() {
+	actorID, recipientID := s.newUserID(), s.newUserID()
+
+	s.decide(actorID, recipientID, model.DecisionLike)
+	s.decide(recipientID, actorID, model.DecisionLike)
+
+	matchedAt := s.likers(s.repository.GetLikedUser, recipientID)[0].MatchedAt
+
+	s.decide(actorID, recipientID, model.DecisionPass)
+
+	_, err := s.repository.UndoDecision(context.Background(), actorID, undoWindow)
+	s.Require().NoError(err)
+
+	for _, userID := range []string{actorID, recipientID} {
+		likers := s.likers(s.repository.GetLikedUser, userID)
+		s.Require().Len(likers, 1)
+		s.True(likers[0].Matched)
+		s.Equal(matchedAt, likers[0].MatchedAt)
+		s.Empty(s.likerIDs(s.repository.GetNewLikedUser, userID))
+	}
+}
+
+func (s *ConformanceSuite) TestUndoGivesBackSuperLike() {
+	actorID := s.newUserID()
+
+	for range superLikeLimit {
+		s.decide(actorID, s.newUserID(), model.DecisionSuperLike)
+	}
+
+	_, err := s.repository.UndoDecision(context.Background(), actorID, undoWindow)
+	s.Require().NoError(err)
+
+	s.decide(actorID, s.newUserID(), model.DecisionSuperLike)
+}
+
+func (s *ConformanceSuite) TestUndoOnlyLatestDecisionWithinWindow() {
+	actorID, recipientID := s.newUserID(), s.newUserID()
+
+	_, err := s.repository.UndoDecision(context.Background(), actorID, undoWindow)
+	s.Require().ErrorIs(err, repository.ErrNotFound)
+
+	s.decide(actorID, s.newUserID(), model.DecisionLike)
+	s.decide(actorID, recipientID, model.DecisionLike)
+
+	_, err = s.repository.UndoDecision(context.Background(), actorID, undoWindow)
+	s.Require().NoError(err)
+
+	// only the latest decision can be undone
+	_, err = s.repository.UndoDecision(context.Background(), actorID, undoWindow)
+	s.Require().ErrorIs(err, repository.ErrNotFound)
+
+	s.decide(actorID, recipientID, model.DecisionLike)
+
+	time.Sleep(10 * time.Millisecond)
+
+	_, err = s.repository.UndoDecision(context.Background(), actorID, time.Millisecond)
+	s.Require().ErrorIs(err, repository.ErrNotFound)
+	s.Equal([]string{actorID}, s.likerIDs(s.repository.GetLikedUser, recipientID))
+}
+
+func (s *ConformanceSuite) TestUnmatchHidesPairFromBothUsers() {
+	firstID, secondID := s.newUserID(), s.newUserID()
+
+	s.decide(firstID, secondID, model.DecisionLike)
+	s.decide(secondID, firstID, model.DecisionLike)
+
+	otherLikerID := s.newUserID()
+	s.decide(otherLikerID, firstID, model.DecisionLike)
+
+	err := s.repository.Unmatch(context.Background(), secondID, firstID, model.UnmatchReasonNoConnection)
+	s.Require().NoError(err)
+
+	s.Equal([]string{otherLikerID}, s.likerIDs(s.repository.GetLikedUser, firstID))
+	s.Equal([]string{otherLikerID}, s.likerIDs(s.repository.GetNewLikedUser, firstID))
+	s.Empty(s.likerIDs(s.repository.GetLikedUser, secondID))
+	s.Empty(s.likerIDs(s.repository.GetNewLikedUser, secondID))
+
+	count, err := s.repository.CountLikedUser(context.Background(), firstID)
+	s.Require().NoError(err)
+	s.Equal(uint64(1), count)
+
+	count, err = s.repository.CountLikedUser(context.Background(), secondID)
+	s.Require().NoError(err)
+	s.Zero(count)
+}
+
+func (s *ConformanceSuite) TestUnmatchedUsersDoNotMatchAgain() {
+	firstID, secondID := s.newUserID(), s.newUserID()
+
+	s.decide(firstID, secondID, model.DecisionLike)
+	s.decide(secondID, firstID, model.DecisionLike)
+
+	err := s.repository.Unmatch(context.Background(), firstID, secondID, model.UnmatchReasonOther)
+	s.Require().NoError(err)
+
+	s.decide(firstID, secondID, model.DecisionPass)
+
+	result, err := s.repository.MakeDecision(
+		context.Background(),
+		firstID,
+		secondID,
+		model.DecisionLike,
+		superLikeLimit,
+	)
+	s.Require().NoError(err)
+	s.False(result.MutualLikes)
+
+	// undoing the decisions made before the unmatch does not bring the match back either
+	_, err = s.repository.UndoDecision(context.Background(), secondID, undoWindow)
+	s.Require().NoError(err)
+
+	s.decide(secondID, firstID, model.DecisionLike)
+
+	s.Empty(s.likerIDs(s.repository.GetLikedUser, firstID))
+	s.Empty(s.likerIDs(s.repository.GetLikedUser, secondID))
+}
+
+func (s *ConformanceSuite) TestUnmatchRequiresMatch() {
+	firstID, secondID := s.newUserID(), s.newUserID()
+
+	err := s.repository.Unmatch(context.Background(), firstID, secondID, model.UnmatchReasonOther)
+	s.Require().ErrorIs(err, repository.ErrNotFound)
+
+	s.decide(firstID, secondID, model.DecisionLike)
+
+	err = s.repository.Unmatch(context.Background(), firstID, secondID, model.UnmatchReasonOther)
+	s.Require().ErrorIs(err, repository.ErrNotFound)
+
+	s.decide(secondID, firstID, model.DecisionLike)
+
+	err = s.repository.Unmatch(context.Background(), firstID, secondID, model.UnmatchReasonMetSomeone)
+	s.Require().NoError(err)
+
+	err = s.repository.Unmatch(context.Background(), secondID, firstID, model.UnmatchReasonOther)
+	s.Require().ErrorIs(err, repository.ErrNotFound)
+}
+
+func (s *ConformanceSuite) TestBlockHidesUsersFromEachOther() {
+	blockerID, blockedID, otherLikerID := s.newUserID(), s.newUserID(), s.newUserID()
+
+	s.decide(blockerID, blockedID, model.DecisionLike)
+	s.decide(blockedID, blockerID, model.DecisionLike)
+	s.decide(otherLikerID, blockerID, model.DecisionLike)
+	s.decide(otherLikerID, blockedID, model.DecisionLike)
+
+	s.Require().NoError(s.repository.BlockUser(context.Background(), blockerID, blockedID))
+
+	for _, userID := range []string{blockerID, blockedID} {
+		s.Equal([]string{otherLikerID}, s.likerIDs(s.repository.GetLikedUser, userID))
+		s.Equal([]string{otherLikerID}, s.likerIDs(s.repository.GetNewLikedUser, userID))
+
+		count, err := s.repository.CountLikedUser(context.Background(), userID)
+		s.Require().NoError(err)
+		s.Equal(uint64(1), count)
+	}
+}
+
+func (s *ConformanceSuite) TestBlockedUsersCannotDecide() {
+	blockerID, blockedID := s.newUserID(), s.newUserID()
+
+	s.decide(blockedID, blockerID, model.DecisionPass)
+
+	s.Require().NoError(s.repository.BlockUser(context.Background(), blockerID, blockedID))
+
+	for _, users := range [][2]string{{blockerID, blockedID}, {blockedID, blockerID}} {
+		_, err := s.repository.MakeDecision(
+			context.Background(),
+			users[0],
+			users[1],
+			model.DecisionLike,
+			superLikeLimit,
+		)
+		s.Require().ErrorIs(err, repository.ErrForbidden)
+	}
+
+	_, err := s.repository.UndoDecision(context.Background(), blockedID, undoWindow)
+	s.Require().ErrorIs(err, repository.ErrForbidden)
+}
+
+func (s *ConformanceSuite) TestUnblockShowsLikesAgain() {
+	blockerID, blockedID := s.newUserID(), s.newUserID()
+
+	s.decide(blockedID, blockerID, model.DecisionLike)
+
+	s.Require().NoError(s.repository.BlockUser(context.Background(), blockerID, blockedID))
+	s.Empty(s.likerIDs(s.repository.GetLikedUser, blockerID))
+
+	s.Require().NoError(s.repository.UnblockUser(context.Background(), blockerID, blockedID))
+	s.Equal([]string{blockedID}, s.likerIDs(s.repository.GetLikedUser, blockerID))
+
+	result, err := s.repository.MakeDecision(
+		context.Background(),
+		blockerID,
+		blockedID,
+		model.DecisionLike,
+		superLikeLimit,
+	)
+	s.Require().NoError(err)
+	s.True(result.MutualLikes)
+}
+
+func (s *ConformanceSuite) TestBlockDissolvesMatchForGood() {
+	blockerID, blockedID := s.newUserID(), s.newUserID()
+
+	s.decide(blockerID, blockedID, model.DecisionLike)
+	s.decide(blockedID, blockerID, model.DecisionLike)
+
+	s.Require().NoError(s.repository.BlockUser(context.Background(), blockedID, blockerID))
+	s.Require().NoError(s.repository.UnblockUser(context.Background(), blockedID, blockerID))
+
+	result, err := s.repository.MakeDecision(
+		context.Background(),
+		blockerID,
+		blockedID,
+		model.DecisionLike,
+		superLikeLimit,
+	)
+	s.Require().NoError(err)
+	s.False(result.MutualLikes)
+
+	s.Empty(s.likerIDs(s.repository.GetLikedUser, blockerID))
+	s.Empty(s.likerIDs(s.repository.GetLikedUser, blockedID))
+}
+
+func (s *ConformanceSuite) TestBlockedUsersAreListedLatestFirst() {
+	blockerID, firstBlockedID, secondBlockedID := s.newUserID(), s.newUserID(), s.newUserID()
+
+	s.Require().NoError(s.repository.BlockUser(context.Background(), blockerID, firstBlockedID))
+	time.Sleep(2 * time.Millisecond)
+	s.Require().NoError(s.repository.BlockUser(context.Background(), blockerID, secondBlockedID))
+
+	firstBlocks, err := s.repository.GetBlockedUsers(context.Background(), blockerID)
+	s.Require().NoError(err)
+	s.Require().Len(firstBlocks, 2)
+	s.Equal(secondBlockedID, firstBlocks[0].BlockedUserID)
+	s.Equal(firstBlockedID, firstBlocks[1].BlockedUserID)
+
+	// blocking again keeps the original time of the block
+	time.Sleep(2 * time.Millisecond)
+	s.Require().NoError(s.repository.BlockUser(context.Background(), blockerID, firstBlockedID))
+
+	blocks, err := s.repository.GetBlockedUsers(context.Background(), blockerID)
+	s.Require().NoError(err)
+	s.Equal(firstBlocks, blocks)
+
+	blocks, err = s.repository.GetBlockedUsers(context.Background(), firstBlockedID)
+	s.Require().NoError(err)
+	s.Empty(blocks)
+
+	err = s.repository.UnblockUser(context.Background(), firstBlockedID, blockerID)
+	s.Require().ErrorIs(err, repository.ErrNotFound)
+
+	s.Require().NoError(s.repository.UnblockUser(context.Background(), blockerID, firstBlockedID))
+
+	err = s.repository.UnblockUser(context.Background(), blockerID, firstBlockedID)
+	s.Require().ErrorIs(err, repository.ErrNotFound)
+}
+
+func (s *ConformanceSuite) TestReportBlocksReportedUser() {
+	reporterID, reportedID := s.newUserID(), s.newUserID()
+
+	s.decide(reportedID, reporterID, model.DecisionLike)
+
+	report := s.report(reporterID, reportedID)
+	s.NotEmpty(report.ID)
+	s.Equal(model.ReportStateOpen, report.State)
+	s.Equal(model.ReportReasonSpam, report.Reason)
+	s.Equal([]string{"message:1"}, report.EvidenceRefs)
+	s.False(report.CreatedAt.IsZero())
+	s.True(report.ResolvedAt.IsZero())
+
+	s.Empty(s.likerIDs(s.repository.GetLikedUser, reporterID))
+
+	blocks, err := s.repository.GetBlockedUsers(context.Background(), reporterID)
+	s.Require().NoError(err)
+	s.Require().Len(blocks, 1)
+	s.Equal(reportedID, blocks[0].BlockedUserID)
+
+	_, err = s.repository.MakeDecision(context.Background(), reportedID, reporterID, model.DecisionLike, superLikeLimit)
+	s.Require().ErrorIs(err, repository.ErrForbidden)
+}
+
+func (s *ConformanceSuite) TestReportsHideUserUntilReviewed() {
+	reportedID, likedID := s.newUserID(), s.newUserID()
+	firstReporterID, secondReporterID := s.newUserID(), s.newUserID()
+
+	s.decide(reportedID, likedID, model.DecisionLike)
+
+	// reporting the same user again does not count the reporter twice
+	firstResult := s.reportUser(firstReporterID, reportedID)
+	s.False(firstResult.HiddenChanged)
+	s.False(s.reportUser(firstReporterID, reportedID).HiddenChanged)
+	s.Equal([]string{reportedID}, s.likerIDs(s.repository.GetLikedUser, likedID))
+
+	s.True(s.reportUser(secondReporterID, reportedID).HiddenChanged)
+	s.Empty(s.likerIDs(s.repository.GetLikedUser, likedID))
+	s.Empty(s.likerIDs(s.repository.GetNewLikedUser, likedID))
+
+	count, err := s.repository.CountLikedUser(context.Background(), likedID)
+	s.Require().NoError(err)
+	s.Zero(count)
+
+	// dismissing one of the reports of the first reporter leaves the other one open
+	result, err := s.repository.ResolveReport(
+		context.Background(),
+		firstResult.Report.ID,
+		model.ReportStateDismissed,
+		"",
+		hideThreshold,
+	)
+	s.Require().NoError(err)
+	s.False(result.HiddenChanged)
+	s.Empty(s.likerIDs(s.repository.GetLikedUser, likedID))
+
+	for _, report := range s.reportsOf(reportedID, model.ReportStateOpen) {
+		if report.ReporterUserID == firstReporterID {
+			result, err = s.repository.ResolveReport(
+				context.Background(),
+				report.ID,
+				model.ReportStateActioned,
+				"warned",
+				hideThreshold,
+			)
+			s.Require().NoError(err)
+			s.True(result.HiddenChanged)
+		}
+	}
+
+	s.Equal([]string{reportedID}, s.likerIDs(s.repository.GetLikedUser, likedID))
+}
+
+func (s *ConformanceSuite) TestReportIsResolvedOnlyOnce() {
+	reporterID, reportedID := s.newUserID(), s.newUserID()
+
+	report := s.report(reporterID, reportedID)
+
+	result, err := s.repository.ResolveReport(
+		context.Background(),
+		report.ID,
+		model.ReportStateActioned,
+		"profile removed",
+		hideThreshold,
+	)
+	s.Require().NoError(err)
+	s.False(result.HiddenChanged)
+
+	resolved := result.Report
+	s.Equal(report.ID, resolved.ID)
+	s.Equal(model.ReportStateActioned, resolved.State)
+	s.Equal("profile removed", resolved.ResolutionNote)
+	s.False(resolved.ResolvedAt.IsZero())
+
+	s.Equal([]model.Report{resolved}, s.reportsOf(reportedID, model.ReportStateActioned))
+	s.Empty(s.reportsOf(reportedID, model.ReportStateOpen))
+
+	_, err = s.repository.ResolveReport(
+		context.Background(),
+		report.ID,
+		model.ReportStateDismissed,
+		"",
+		hideThreshold,
+	)
+	s.Require().ErrorIs(err, repository.ErrNotFound)
+
+	_, err = s.repository.ResolveReport(
+		context.Background(),
+		s.newUserID(),
+		model.ReportStateDismissed,
+		"",
+		hideThreshold,
+	)
+	s.Require().ErrorIs(err, repository.ErrNotFound)
+}
+
+func (s *ConformanceSuite) TestReportsAreListedOldestFirst() {
+	reportedID := s.newUserID()
+
+	var reportIDs []string
+
+	for range 3 {
+		reportIDs = append(reportIDs, s.report(s.newUserID(), reportedID).ID)
+	}
+
+	listed := s.reportsOf(reportedID, "")
+	ids := make([]string, 0, len(listed))
+
+	for _, report := range listed {
+		ids = append(ids, report.ID)
+	}
+
+	s.Equal(reportIDs, ids)
+}
+
+func (s *ConformanceSuite) TestDecisionsAreWatchedByBothUsers() {
+	firstID, secondID := s.newUserID(), s.newUserID()
+	firstEvents, secondEvents := s.subscribe(firstID, ""), s.subscribe(secondID, "")
+
+	s.decide(firstID, secondID, model.DecisionSuperLike)
+
+	event, _ := s.nextEvent(secondEvents)
+	s.Equal(model.EventKindLike, event.Kind)
+	s.Equal(firstID, event.OtherUserID)
+	s.True(event.SuperLiked)
+
+	s.decide(secondID, firstID, model.DecisionLike)
+
+	for userID, events := range map[string]repository.Subscription{firstID: firstEvents, secondID: secondEvents} {
+		event, _ = s.nextEvent(events)
+		s.Equal(model.EventKindMatch, event.Kind)
+		s.Equal(userID, event.UserID)
+		s.False(event.SuperLiked)
+	}
+
+	s.Require().NoError(s.repository.Unmatch(context.Background(), firstID, secondID, model.UnmatchReasonOther))
+
+	for _, events := range []repository.Subscription{firstEvents, secondEvents} {
+		event, _ = s.nextEvent(events)
+		s.Equal(model.EventKindUnmatch, event.Kind)
+	}
+}
+
+func (s *ConformanceSuite) TestUndoneLikeIsWatchedAsUnmatch() {
+	firstID, secondID := s.newUserID(), s.newUserID()
+
+	s.decide(firstID, secondID, model.DecisionLike)
+
+	events := s.subscribe(firstID, "")
+
+	s.decide(secondID, firstID, model.DecisionLike)
+
+	event, _ := s.nextEvent(events)
+	s.Equal(model.EventKindMatch, event.Kind)
+	s.Equal(secondID, event.OtherUserID)
+
+	_, err := s.repository.UndoDecision(context.Background(), secondID, undoWindow)
+	s.Require().NoError(err)
+
+	event, _ = s.nextEvent(events)
+	s.Equal(model.EventKindUnmatch, event.Kind)
+	s.Equal(secondID, event.OtherUserID)
+}
+
+func (s *ConformanceSuite) TestWatchResumesAfterToken() {
+	recipientID, firstID, secondID := s.newUserID(), s.newUserID(), s.newUserID()
+	events := s.subscribe(recipientID, "")
+
+	s.decide(firstID, recipientID, model.DecisionLike)
+
+	event, resumeToken := s.nextEvent(events)
+	s.Equal(firstID, event.OtherUserID)
+	s.Require().NoError(events.Close(context.Background()))
+
+	// the like made while the recipient is not subscribed is delivered once the subscription is resumed
+	s.decide(secondID, recipientID, model.DecisionLike)
+
+	events = s.subscribe(recipientID, resumeToken)
+
+	event, _ = s.nextEvent(events)
+	s.Equal(model.EventKindLike, event.Kind)
+	s.Equal(secondID, event.OtherUserID)
+
+	// and the resumed subscription goes on with the likes made after it caught up
+	thirdID := s.newUserID()
+	s.decide(thirdID, recipientID, model.DecisionLike)
+
+	event, _ = s.nextEvent(events)
+	s.Equal(thirdID, event.OtherUserID)
+}
+
+func (s *ConformanceSuite) TestWatchResumesAfterTokenWhileOthersWatch() {
+	recipientID, firstID, secondID, thirdID := s.newUserID(), s.newUserID(), s.newUserID(), s.newUserID()
+
+	// the subscription of another user keeps watching the events while the recipient is away
+	s.subscribe(s.newUserID(), "")
+
+	events := s.subscribe(recipientID, "")
+
+	s.decide(firstID, recipientID, model.DecisionLike)
+
+	_, resumeToken := s.nextEvent(events)
+	s.Require().NoError(events.Close(context.Background()))
+
+	s.decide(secondID, recipientID, model.DecisionLike)
+
+	events = s.subscribe(recipientID, resumeToken)
+
+	event, _ := s.nextEvent(events)
+	s.Equal(secondID, event.OtherUserID)
+
+	s.decide(thirdID, recipientID, model.DecisionLike)
+
+	event, _ = s.nextEvent(events)
+	s.Equal(thirdID, event.OtherUserID)
+}
+
+func (s *ConformanceSuite) TestHiddenUsersAreNotWatched() {
+	hiddenID, likedID, likerID := s.newUserID(), s.newUserID(), s.newUserID()
+
+	s.report(s.newUserID(), hiddenID)
+	s.report(s.newUserID(), hiddenID)
+
+	events := s.subscribe(likedID, "")
+
+	s.decide(hiddenID, likedID, model.DecisionLike)
+	s.decide(likerID, likedID, model.DecisionLike)
+
+	event, _ := s.nextEvent(events)
+	s.Equal(likerID, event.OtherUserID)
+}
+
+func (s *ConformanceSuite) TestMalformedResumeTokenIsRejected() {
+	watcher := s.watcher()
+
+	_, err := watcher.SubscribeEvents(context.Background(), s.newUserID(), "not a token")
+	s.Require().ErrorIs(err, repository.ErrInvalidInput)
+}
+
+func (s *ConformanceSuite) TestOutboxEventsAreNumberedPerPair() {
+	firstID, secondID := s.newUserID(), s.newUserID()
+
+	s.decide(firstID, secondID, model.DecisionLike)
+	s.decide(secondID, firstID, model.DecisionSuperLike)
+	s.decide(s.newUserID(), secondID, model.DecisionLike)
+	s.Require().NoError(s.repository.Unmatch(context.Background(), firstID, secondID, model.UnmatchReasonOther))
+
+	events := s.pendingOutboxEvents(firstID, secondID)
+	s.Require().Len(events, 4)
+
+	expected := []struct {
+		eventType model.OutboxEventType
+		actorID   string
+	}{
+		{eventType: model.OutboxEventLikeCreated, actorID: firstID},
+		{eventType: model.OutboxEventLikeCreated, actorID: secondID},
+		{eventType: model.OutboxEventMatchCreated, actorID: secondID},
+		{eventType: model.OutboxEventMatchDissolved, actorID: firstID},
+	}
+
+	for i, event := range events {
+		s.Equal(int64(i+1), event.Sequence)
+		s.Equal(expected[i].eventType, event.Type)
+		s.Equal(expected[i].actorID, event.ActorUserID)
+		s.NotEmpty(event.ID)
+		s.False(event.OccurredAt.IsZero())
+	}
+
+	s.True(events[1].SuperLiked)
+	s.Equal(string(model.UnmatchReasonOther), events[3].Reason)
+}
+
+func (s *ConformanceSuite) TestUndoneLikeWritesDissolvedOutboxEvent() {
+	firstID, secondID := s.newUserID(), s.newUserID()
+
+	s.decide(firstID, secondID, model.DecisionLike)
+	s.decide(secondID, firstID, model.DecisionLike)
+
+	_, err := s.repository.UndoDecision(context.Background(), secondID, undoWindow)
+	s.Require().NoError(err)
+
+	events := s.pendingOutboxEvents(firstID, secondID)
+	s.Require().Len(events, 4)
+	s.Equal(model.OutboxEventMatchDissolved, events[3].Type)
+	s.Equal(secondID, events[3].ActorUserID)
+	s.Equal(model.DissolvedReasonUndone, events[3].Reason)
+}
+
+func (s *ConformanceSuite) TestPublishedOutboxEventsAreNotPending() {
+	firstID, secondID := s.newUserID(), s.newUserID()
+
+	s.decide(firstID, secondID, model.DecisionLike)
+	s.decide(secondID, firstID, model.DecisionLike)
+
+	leaseTerm := s.outboxLeaseTerm()
+
+	events := s.pendingOutboxEvents(firstID, secondID)
+	s.Require().Len(events, 3)
+	s.Require().NoError(s.outboxStore().MarkOutboxEventPublished(context.Background(), events[0].ID, leaseTerm))
+
+	pending := s.pendingOutboxEvents(firstID, secondID)
+	s.Require().Len(pending, 2)
+	s.Equal(events[1:], pending)
+
+	err := s.outboxStore().MarkOutboxEventPublished(context.Background(), events[0].ID, leaseTerm)
+	s.Require().ErrorIs(err, repository.ErrNotFound)
+}
+
+func (s *ConformanceSuite) TestFailedOutboxEventKeepsItsAttempts() {
+	ctx := context.Background()
+	firstID, secondID := s.newUserID(), s.newUserID()
+
+	s.decide(firstID, secondID, model.DecisionLike)
+
+	events := s.pendingOutboxEvents(firstID, secondID)
+	s.Require().Len(events, 1)
+	s.Zero(events[0].Attempts)
+	s.True(events[0].NextAttemptAt.IsZero())
+	s.Empty(events[0].DeliveredTo)
+
+	leaseTerm := s.outboxLeaseTerm()
+	nextAttemptAt := time.Now().UTC().Truncate(time.Millisecond).Add(-time.Minute)
+
+	for range 2 {
+		s.Require().NoError(s.outboxStore().RetryOutboxEvent(ctx, events[0].ID, leaseTerm, nextAttemptAt))
+	}
+
+	endpoints := []string{"http://localhost/first", "http://localhost/second"}
+	s.Require().NoError(s.deadLetterStore().MarkOutboxEventDelivered(ctx, events[0].ID, endpoints[:1]))
+	s.Require().NoError(s.deadLetterStore().MarkOutboxEventDelivered(ctx, events[0].ID, endpoints))
+
+	events = s.pendingOutboxEvents(firstID, secondID)
+	s.Require().Len(events, 1)
+	s.Equal(int64(2), events[0].Attempts)
+	s.True(nextAttemptAt.Equal(events[0].NextAttemptAt))
+	s.Equal(endpoints, events[0].DeliveredTo)
+
+	s.Require().NoError(s.outboxStore().MarkOutboxEventPublished(ctx, events[0].ID, leaseTerm))
+
+	err := s.outboxStore().RetryOutboxEvent(ctx, events[0].ID, leaseTerm, nextAttemptAt)
+	s.Require().ErrorIs(err, repository.ErrNotFound)
+
+	err = s.deadLetterStore().MarkOutboxEventDelivered(ctx, events[0].ID, endpoints)
+	s.Require().ErrorIs(err, repository.ErrNotFound)
+}
+
+func (s *ConformanceSuite) TestOutboxEventsBackingOffAreNotPending() {
+	ctx := context.Background()
+	firstID, secondID := s.newUserID(), s.newUserID()
+
+	s.decide(firstID, secondID, model.DecisionLike)
+
+	leaseTerm := s.outboxLeaseTerm()
+
+	events := s.pendingOutboxEvents(firstID, secondID)
+	s.Require().Len(events, 1)
+
+	s.Require().NoError(s.outboxStore().RetryOutboxEvent(ctx, events[0].ID, leaseTerm, time.Now().Add(time.Hour)))
+	s.Empty(s.pendingOutboxEvents(firstID, secondID))
+
+	s.Require().NoError(s.outboxStore().RetryOutboxEvent(ctx, events[0].ID, leaseTerm, time.Now().Add(-time.Second)))
+	s.Len(s.pendingOutboxEvents(firstID, secondID), 1)
+}
+
+func (s *ConformanceSuite) TestOutboxLeaseIsHeldByOneOwner() {
+	const leaseDuration = 100 * time.Millisecond
+
+	ctx := context.Background()
+	store := s.outboxStore()
+	firstOwner, secondOwner := s.newUserID(), s.newUserID()
+
+	// the lease left by another scenario is waited out
+	var firstTerm int64
+
+	s.Require().Eventually(func() bool {
+		leaseTerm, held, err := store.AcquireOutboxLease(ctx, firstOwner, leaseDuration)
+		firstTerm = leaseTerm
+
+		return err == nil && held
+	}, eventTimeout, 10*time.Millisecond)
+
+	_, held, err := store.AcquireOutboxLease(ctx, secondOwner, leaseDuration)
+	s.Require().NoError(err)
+	s.False(held)
+
+	// extending the lease keeps its term
+	leaseTerm, held, err := store.AcquireOutboxLease(ctx, firstOwner, leaseDuration)
+	s.Require().NoError(err)
+	s.True(held)
+	s.Equal(firstTerm, leaseTerm)
+
+	// the lease the owner stopped extending can be taken by another owner once it expires, in the next term
+	time.Sleep(2 * leaseDuration)
+
+	leaseTerm, held, err = store.AcquireOutboxLease(ctx, secondOwner, leaseDuration)
+	s.Require().NoError(err)
+	s.True(held)
+	s.Greater(leaseTerm, firstTerm)
+}
+
+func (s *ConformanceSuite) TestOutboxEventsAreNotMarkedAfterLeaseIsTakenOver() {
+	ctx := context.Background()
+	firstID, secondID := s.newUserID(), s.newUserID()
+
+	s.decide(firstID, secondID, model.DecisionLike)
+
+	staleTerm := s.outboxLeaseTerm()
+	leaseTerm := s.outboxLeaseTerm()
+	s.Require().Greater(leaseTerm, staleTerm)
+
+	events := s.pendingOutboxEvents(firstID, secondID)
+	s.Require().Len(events, 1)
+
+	err := s.outboxStore().MarkOutboxEventPublished(ctx, events[0].ID, staleTerm)
+	s.Require().ErrorIs(err, repository.ErrConflict)
+
+	err = s.outboxStore().RetryOutboxEvent(ctx, events[0].ID, staleTerm, time.Now().Add(time.Hour))
+	s.Require().ErrorIs(err, repository.ErrConflict)
+
+	events = s.pendingOutboxEvents(firstID, secondID)
+	s.Require().Len(events, 1)
+	s.Zero(events[0].Attempts)
+
+	s.Require().NoError(s.outboxStore().MarkOutboxEventPublished(ctx, events[0].ID, leaseTerm))
+	s.Empty(s.pendingOutboxEvents(firstID, secondID))
+}
+
+func (s *ConformanceSuite) TestDeadLettersAreListedOldestFirst() {
+	ctx := context.Background()
+	store := s.deadLetterStore()
+
+	// the dead letters failed long ago, so they are the oldest ones even if other scenarios saved some as well
+	failedAt := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	deadLetterIDs := make([]string, 0, 3)
+
+	for i := range 3 {
+		deadLetter := s.deadLetter(failedAt.Add(time.Duration(2-i) * time.Minute))
+		s.Require().NoError(store.SaveDeadLetter(ctx, deadLetter))
+
+		deadLetterIDs = append(deadLetterIDs, deadLetter.ID)
+	}
+
+	deadLetters, err := store.GetDeadLetters(ctx, deadLetterIDs[1:], 1)
+	s.Require().NoError(err)
+	s.Require().Len(deadLetters, 2)
+	s.Equal(deadLetterIDs[2], deadLetters[0].ID)
+	s.Equal(deadLetterIDs[1], deadLetters[1].ID)
+
+	deadLetters, err = store.GetDeadLetters(ctx, nil, 2)
+	s.Require().NoError(err)
+	s.Require().Len(deadLetters, 2)
+	s.Equal(deadLetterIDs[2], deadLetters[0].ID)
+	s.Equal(deadLetterIDs[1], deadLetters[1].ID)
+
+	for _, deadLetterID := range deadLetterIDs {
+		s.Require().NoError(store.RemoveDeadLetter(ctx, deadLetterID))
+	}
+}
+
+func (s *ConformanceSuite) TestDeadLettersAreFoundForEarlierEventsOfPair() {
+	ctx := context.Background()
+	store := s.deadLetterStore()
+
+	deadLetter := s.deadLetter(time.Now().UTC().Truncate(time.Millisecond))
+	deadLetter.Event.Sequence = 2
+	s.Require().NoError(store.SaveDeadLetter(ctx, deadLetter))
+
+	pairKey, endpoint := deadLetter.Event.PairKey, deadLetter.Endpoint
+	tests := []struct {
+		name     string
+		pairKey  string
+		endpoint string
+		sequence int64
+		expected bool
+	}{
+		{name: "later event", pairKey: pairKey, endpoint: endpoint, sequence: 3, expected: true},
+		{name: "same event", pairKey: pairKey, endpoint: endpoint, sequence: 2},
+		{name: "earlier event", pairKey: pairKey, endpoint: endpoint, sequence: 1},
+		{name: "other endpoint", pairKey: pairKey, endpoint: "http://localhost/other", sequence: 3},
+		{name: "other pair", pairKey: model.PairKey(s.newUserID(), s.newUserID()), endpoint: endpoint, sequence: 3},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			held, err := store.HasEarlierDeadLetters(ctx, tt.pairKey, tt.endpoint, tt.sequence)
+			s.Require().NoError(err)
+			s.Equal(tt.expected, held)
+		})
+	}
+
+	s.Require().NoError(store.RemoveDeadLetter(ctx, deadLetter.ID))
+
+	held, err := store.HasEarlierDeadLetters(ctx, pairKey, endpoint, 3)
+	s.Require().NoError(err)
+	s.False(held)
+}
+
+func (s *ConformanceSuite) TestDeadLetterIsReplacedUntilRemoved() {
+	ctx := context.Background()
+	store := s.deadLetterStore()
+	deadLetter := s.deadLetter(time.Now().UTC().Truncate(time.Millisecond))
+
+	s.Require().NoError(store.SaveDeadLetter(ctx, deadLetter))
+
+	deadLetter.Attempts++
+	deadLetter.LastError = "unexpected status 503"
+	s.Require().NoError(store.SaveDeadLetter(ctx, deadLetter))
+
+	deadLetters, err := store.GetDeadLetters(ctx, []string{deadLetter.ID}, 1)
+	s.Require().NoError(err)
+	s.Require().Len(deadLetters, 1)
+	s.Equal(deadLetter, deadLetters[0])
+
+	s.Require().NoError(store.RemoveDeadLetter(ctx, deadLetter.ID))
+
+	deadLetters, err = store.GetDeadLetters(ctx, []string{deadLetter.ID}, 1)
+	s.Require().NoError(err)
+	s.Empty(deadLetters)
+
+	s.Require().ErrorIs(store.RemoveDeadLetter(ctx, deadLetter.ID), repository.ErrNotFound)
+}
+
+func (s *ConformanceSuite) TestConcurrentMutualLikesMatchBothUsers() {
+	const attempts = 5
+
+	for range attempts {
+		firstID, secondID := s.newUserID(), s.newUserID()
+
+		results := make([]model.DecisionResult, 2)
+		errs := make([]error, 2)
+		start := make(chan struct{})
+		wg := sync.WaitGroup{}
+
+		for i, users := range [][2]string{{firstID, secondID}, {secondID, firstID}} {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				<-start
+
+				results[i], errs[i] = s.repository.MakeDecision(
+					context.Background(),
+					users[0],
+					users[1],
+					model.DecisionLike,
+					superLikeLimit,
+				)
+			}()
+		}
+
+		close(start)
+		wg.Wait()
+
+		s.Require().NoError(errs[0])
+		s.Require().NoError(errs[1])
+		s.NotEqual(results[0].MutualLikes, results[1].MutualLikes, "exactly one decision should see the mutual like")
+		s.NotEqual(results[0].MatchCreated, results[1].MatchCreated, "exactly one decision should make the match")
+
+		for _, userID := range []string{firstID, secondID} {
+			likers := s.likers(s.repository.GetLikedUser, userID)
+			s.Require().Len(likers, 1)
+			s.True(likers[0].Matched)
+		}
+	}
+}
+
+type listFunc func(ctx context.Context, userID string, page pagination.Page) ([]model.Match, error)
+
+func (s *ConformanceSuite) newUserID() string {
+	id, err := uuid.NewRandom()
+	s.Require().NoError(err)
+
+	return id.String()
+}
+
+func (s *ConformanceSuite) decide(actorID, recipientID string, decision model.Decision) {
+	_, err := s.repository.MakeDecision(context.Background(), actorID, recipientID, decision, superLikeLimit)
+	s.Require().NoError(err)
+}
+
+func (s *ConformanceSuite) report(reporterID, reportedID string) model.Report {
+	return s.reportUser(reporterID, reportedID).Report
+}
+
+func (s *ConformanceSuite) reportUser(reporterID, reportedID string) model.ReportResult {
+	result, err := s.repository.ReportUser(context.Background(), model.Report{
+		ReporterUserID: reporterID,
+		ReportedUserID: reportedID,
+		Reason:         model.ReportReasonSpam,
+		Details:        "sends links",
+		EvidenceRefs:   []string{"message:1"},
+	}, hideThreshold)
+	s.Require().NoError(err)
+
+	// the reports made at the same millisecond would be listed in the order of their random IDs
+	time.Sleep(2 * time.Millisecond)
+
+	return result
+}
+
+// reportsOf pages through all the reports in the state, checking their order, and returns the ones about the user.
+func (s *ConformanceSuite) reportsOf(reportedID string, state model.ReportState) []model.Report {
+	var reports []model.Report
+
+	page := pagination.ReportPage{Limit: 2}
+
+	for {
+		pageReports, err := s.repository.GetReports(context.Background(), state, page)
+		s.Require().NoError(err)
+		s.Require().LessOrEqual(int64(len(pageReports)), page.Limit)
+
+		if len(pageReports) == 0 {
+			return reports
+		}
+
+		for _, report := range pageReports {
+			if state != "" {
+				s.Equal(state, report.State)
+			}
+
+			if page.After != nil {
+				s.True(report.CreatedAt.After(page.After.CreatedAt) ||
+					report.CreatedAt.Equal(page.After.CreatedAt) && report.ID > page.After.ReportID)
+			}
+
+			if report.ReportedUserID == reportedID {
+				reports = append(reports, report)
+			}
+
+			page.After = &pagination.ReportPosition{CreatedAt: report.CreatedAt, ReportID: report.ID}
+		}
+	}
+}
+
+// watcher returns the repository as the Watcher, skipping the test if the repository is not one.
+func (s *ConformanceSuite) watcher() Watcher {
+	watcher, ok := s.repository.(Watcher)
+	if !ok {
+		s.T().Skip("events of the repository cannot be subscribed to")
+	}
+
+	return watcher
+}
+
+func (s *ConformanceSuite) subscribe(userID, resumeToken string) repository.Subscription {
+	subscription, err := s.watcher().SubscribeEvents(context.Background(), userID, resumeToken)
+	s.Require().NoError(err)
+
+	s.T().Cleanup(func() {
+		_ = subscription.Close(context.Background())
+	})
+
+	return subscription
+}
+
+func (s *ConformanceSuite) nextEvent(subscription repository.Subscription) (model.Event, string) {
+	ctx, cancel := context.WithTimeout(context.Background(), eventTimeout)
+	defer cancel()
+
+	event, resumeToken, err := subscription.Next(ctx)
+	s.Require().NoError(err)
+	s.NotEmpty(event.ID)
+	s.False(event.At.IsZero())
+	s.NotEmpty(resumeToken)
+
+	return event, resumeToken
+}
+
+// outboxStore returns the repository as the OutboxStore, skipping the test if the repository is not one.
+func (s *ConformanceSuite) outboxStore() OutboxStore {
+	outboxStore, ok := s.repository.(OutboxStore)
+	if !ok {
+		s.T().Skip("repository does not write outbox events")
+	}
+
+	return outboxStore
+}
+
+// deadLetterStore returns the repository as the DeadLetterStore, skipping the test if the repository is not one.
+func (s *ConformanceSuite) deadLetterStore() DeadLetterStore {
+	store, ok := s.repository.(DeadLetterStore)
+	if !ok {
+		s.T().Skip("repository does not keep dead letters")
+	}
+
+	return store
+}
+
+func (s *ConformanceSuite) deadLetter(failedAt time.Time) model.DeadLetter {
+	actorID, recipientID := s.newUserID(), s.newUserID()
+
+	return model.DeadLetter{
+		ID: s.newUserID(),
+		Event: model.OutboxEvent{
+			ID:              s.newUserID(),
+			Type:            model.OutboxEventMatchCreated,
+			PairKey:         model.PairKey(actorID, recipientID),
+			Sequence:        1,
+			ActorUserID:     actorID,
+			RecipientUserID: recipientID,
+			OccurredAt:      failedAt.Add(-time.Minute),
+		},
+		Endpoint:  "http://localhost/webhooks",
+		Attempts:  5,
+		LastError: "unexpected status 500",
+		FailedAt:  failedAt,
+	}
+}
+
+// outboxLeaseTerm takes the outbox lease over for a moment and returns its term, the lease left by another scenario
+// is waited out.
+func (s *ConformanceSuite) outboxLeaseTerm() int64 {
+	// the scenario is skipped before waiting, as it cannot be skipped from the goroutine of the condition
+	store := s.outboxStore()
+
+	var leaseTerm int64
+
+	s.Require().Eventually(func() bool {
+		term, held, err := store.AcquireOutboxLease(context.Background(), s.newUserID(), time.Millisecond)
+		leaseTerm = term
+
+		return err == nil && held
+	}, eventTimeout, 10*time.Millisecond)
+
+	return leaseTerm
+}
+
+// pendingOutboxEvents returns the pending outbox events of the pair, other pairs may have pending events as well.
+func (s *ConformanceSuite) pendingOutboxEvents(userID, otherUserID string) []model.OutboxEvent {
+	events, err := s.outboxStore().GetPendingOutboxEvents(context.Background(), math.MaxInt64)
+	s.Require().NoError(err)
+
+	pairKey := model.PairKey(userID, otherUserID)
+
+	return slices.DeleteFunc(events, func(event model.OutboxEvent) bool {
+		return event.PairKey != pairKey
+	})
+}
+
+func (s *ConformanceSuite) likers(list listFunc, userID string) []model.Match {
+	likers, err := list(context.Background(), userID, pagination.Page{
+		Sort:  pagination.SortByActor,
+		Order: pagination.Ascending,
+	})
+	s.Require().NoError(err)
+
+	return likers
+}
+
+func (s *ConformanceSuite) likerIDs(list listFunc, userID string) []string {
+	likers := s.likers(list, userID)
+	ids := make([]string, 0, len(likers))
+
+	for i := range likers {
+		ids = append(ids, likers[i].ActorUserID)
+	}
+
+	return ids
+}
+
+func (s *ConformanceSuite) allLikers(list listFunc, userID string, page pagination.Page) []model.Match {
+
+	var likers []model.Match
+
+	for {
+		pageLikers, err := list(context.Background(), userID, page)
+		s.Require().NoError(err)
+		s.Require().LessOrEqual(int64(len(pageLikers)), page.Limit)
+
+		if len(pageLikers) == 0 {
+			return likers
+		}
+
+		likers = append(likers, pageLikers...)
+		last := pageLikers[len(pageLikers)-1]
+		page.After = &pagination.Position{
+			SuperLiked:  last.SuperLiked(),
+			LikedAt:     last.UpdatedAt,
+			ActorUserID: last.ActorUserID,
+		}
+	}
+}
+
+func (s *ConformanceSuite) requireSorted(likers []model.Match, page pagination.Page) {
+	sorted := slices.IsSortedFunc(likers, func(a, b model.Match) int {
+		if page.SuperLikesFirst && a.SuperLiked() != b.SuperLiked() {
+			if a.SuperLiked() {
+				return -1
+			}
+
+			return 1
+		}
+
+		result := cmp.Compare(a.ActorUserID, b.ActorUserID)
+
+		if page.Sort == pagination.SortByTime {
+			if timeResult := a.UpdatedAt.Compare(b.UpdatedAt); timeResult != 0 {
+				result = timeResult
+			}
+		}
+
+		if page.Order == pagination.Descending {
+			result = -result
+		}
+
+		return result
+	})
+
+	s.True(sorted, "likers are not sorted by %s in %s order, super likes first: %t",
+		page.Sort, page.Order, page.SuperLikesFirst)
+}
@@ -0,0 +1,49 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/PatrykPasterny/dating-engine/internal/tracing"
+)
+
+func TestLogHandlerAddsTraceIDs(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := slog.New(tracing.NewLogHandler(slog.NewJSONHandler(&buf, nil))).With(slog.String("user", "test"))
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "test")
+	defer span.End()
+
+	logger.InfoContext(ctx, "traced")
+	logger.Info("not traced")
+
+	decoder := json.NewDecoder(&buf)
+
+	var traced, notTraced map[string]any
+
+	require.NoError(t, decoder.Decode(&traced))
+	require.NoError(t, decoder.Decode(&notTraced))
+
+	require.Equal(t, span.SpanContext().TraceID().String(), traced["trace_id"])
+	require.Equal(t, span.SpanContext().SpanID().String(), traced["span_id"])
+	require.Equal(t, "test", traced["user"])
+	require.NotContains(t, notTraced, "trace_id")
+}
+
+func TestSetupRejectsUnknownExporter(t *testing.T) {
+	_, err := tracing.Setup(context.Background(), "jaeger", "", 1)
+	require.Error(t, err)
+}
+
+func TestSetupWithoutExporter(t *testing.T) {
+	shutdown, err := tracing.Setup(context.Background(), tracing.ExporterNone, "", 1)
+	require.NoError(t, err)
+	require.NoError(t, shutdown(context.Background()))
+}
@@ -2,67 +2,382 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log/slog"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/redis/go-redis/v9"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
+	"github.com/PatrykPasterny/dating-engine/internal/cache"
 	"github.com/PatrykPasterny/dating-engine/internal/config"
+	healthcheck "github.com/PatrykPasterny/dating-engine/internal/health"
+	"github.com/PatrykPasterny/dating-engine/internal/logging"
+	"github.com/PatrykPasterny/dating-engine/internal/metrics"
+	"github.com/PatrykPasterny/dating-engine/internal/outbox"
+	"github.com/PatrykPasterny/dating-engine/internal/pagination"
 	"github.com/PatrykPasterny/dating-engine/internal/repository"
+	"github.com/PatrykPasterny/dating-engine/internal/tracing"
 	"github.com/PatrykPasterny/dating-engine/transfer/protobuf/api"
 	pb "github.com/PatrykPasterny/dating-engine/transfer/protobuf/definition"
 )
 
-const configPath = "./internal/config/config.yml"
-
 func main() {
+	// the level and the hashing are set once the configuration is loaded and change whenever it is reloaded
+	logLevel := new(slog.LevelVar)
+	userIDHashing := new(logging.UserIDHashing)
+
 	logger := slog.New(
-		slog.NewJSONHandler(
-			os.Stderr,
-			&slog.HandlerOptions{
-				Level: slog.LevelDebug,
-			},
+		tracing.NewLogHandler(
+			slog.NewJSONHandler(
+				os.Stderr,
+				&slog.HandlerOptions{
+					Level:       logLevel,
+					ReplaceAttr: userIDHashing.ReplaceAttr,
+				},
+			),
 		),
 	)
 
 	logger.Info("the explorer service is starting")
 
-	cfg, err := config.GetConfig(configPath)
-	if err != nil {
-		logger.Error("failed getting configuration", err)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, logger, logLevel, userIDHashing); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+
+		logger.Error("the explorer service failed", slog.Any("error", err))
+
+		stop()
+		os.Exit(1)
+	}
 
-		return
+	logger.Info("the explorer service stopped")
+}
+
+func run(
+	ctx context.Context,
+	logger *slog.Logger,
+	logLevel *slog.LevelVar,
+	userIDHashing *logging.UserIDHashing,
+) error {
+	loadConfig := func() (*config.Config, error) {
+		return config.Load(os.Args[1:], os.LookupEnv)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("getting configuration: %w", err)
 	}
 
-	clientOpts := options.Client().ApplyURI(cfg.Database.URI)
+	logLevel.Set(cfg.Level())
+	userIDHashing.SetEnabled(cfg.Logging.HashUserIDs)
 
-	mongoClient, err := mongo.Connect(context.Background(), clientOpts)
+	logger.Info("configuration loaded", slog.String("path", cfg.Path), slog.Any("config", cfg))
+
+	if cfg.Pagination.Secret == "" {
+		logger.Warn("pagination secret is not configured, pagination tokens will be valid only for this instance")
+	}
+
+	cursorCodec, err := pagination.NewCursorCodec([]byte(cfg.Pagination.Secret))
 	if err != nil {
-		logger.Error("failed connecting to mongoDB instance", err)
+		return fmt.Errorf("creating pagination cursor codec: %w", err)
+	}
 
-		return
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing.Exporter, cfg.Tracing.Endpoint, cfg.Tracing.SampleRatio)
+	if err != nil {
+		return fmt.Errorf("setting up tracing: %w", err)
 	}
 
 	defer func() {
-		if err = mongoClient.Disconnect(context.Background()); err != nil {
-			logger.Error("failed disconnecting from mongoDB instance", err)
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+		defer cancel()
 
-			return
+		if err = shutdownTracing(shutdownCtx); err != nil {
+			logger.Error("failed flushing traces", slog.Any("error", err))
 		}
 	}()
 
-	collection := mongoClient.Database(cfg.Database.Name).Collection(cfg.Database.Collection)
+	loggingInterceptor := logging.NewInterceptor(logger, cfg.Logging.SuccessSampleRatio)
+
+	var (
+		serviceMetrics     *metrics.Metrics
+		repositoryOpts     []repository.Option
+		unaryInterceptors  = []grpc.UnaryServerInterceptor{loggingInterceptor.UnaryServerInterceptor}
+		streamInterceptors = []grpc.StreamServerInterceptor{loggingInterceptor.StreamServerInterceptor}
+	)
+
+	if cfg.Metrics.Enabled {
+		serviceMetrics = metrics.New()
+		repositoryOpts = append(repositoryOpts, repository.WithTransactionObserver(serviceMetrics))
+		unaryInterceptors = append(unaryInterceptors, serviceMetrics.UnaryServerInterceptor)
+		streamInterceptors = append(streamInterceptors, serviceMetrics.StreamServerInterceptor)
+
+		go func() {
+			address := net.JoinHostPort(cfg.Server.Host, cfg.Metrics.Port)
+
+			if err := serviceMetrics.Serve(ctx, logger, address); err != nil {
+				logger.Error("failed exposing metrics", slog.Any("error", err))
+			}
+		}()
+	}
+
+	healthServer := health.NewServer()
+
+	var (
+		matchRepository api.MatchRepository
+		eventWatcher    api.EventWatcher
+		outboxStore     outbox.Store
+		deadLetterStore outbox.DeadLetterStore
+	)
 
-	exploreRepository := repository.NewExploreRepository(mongoClient, collection)
+	switch cfg.Database.Driver {
+	case config.DatabaseDriverMemory:
+		logger.Warn("matches are kept in memory and will be lost once the service stops")
 
-	var opts []grpc.ServerOption
+		memoryRepository := repository.NewMemoryRepository()
+		matchRepository, eventWatcher = memoryRepository, memoryRepository
+		outboxStore, deadLetterStore = memoryRepository, memoryRepository
+
+		healthServer.SetServingStatus(pb.ExploreService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	case config.DatabaseDriverMongo:
+		clientOpts := options.Client().
+			ApplyURI(cfg.Database.URI).
+			SetMonitor(otelmongo.NewMonitor())
+
+		mongoClient, err := mongo.Connect(context.Background(), clientOpts)
+		if err != nil {
+			return fmt.Errorf("connecting to mongoDB instance: %w", err)
+		}
+
+		defer func() {
+			if err = mongoClient.Disconnect(context.Background()); err != nil {
+				logger.Error("failed disconnecting from mongoDB instance", slog.Any("error", err))
+
+				return
+			}
+		}()
+
+		database := mongoClient.Database(cfg.Database.Name)
+
+		exploreRepository := repository.NewExploreRepository(
+			mongoClient,
+			repository.Collections{
+				Matches:         database.Collection(cfg.Database.Collection),
+				SuperLikes:      database.Collection(cfg.Database.SuperLikesCollection),
+				Decisions:       database.Collection(cfg.Database.DecisionsCollection),
+				Blocks:          database.Collection(cfg.Database.BlocksCollection),
+				Reports:         database.Collection(cfg.Database.ReportsCollection),
+				ReportedUsers:   database.Collection(cfg.Database.ReportedUsersCollection),
+				Events:          database.Collection(cfg.Database.EventsCollection),
+				Outbox:          database.Collection(cfg.Database.OutboxCollection),
+				OutboxSequences: database.Collection(cfg.Database.OutboxSequencesCollection),
+				OutboxLeases:    database.Collection(cfg.Database.OutboxLeasesCollection),
+				DeadLetters:     database.Collection(cfg.Database.DeadLettersCollection),
+			},
+			repositoryOpts...,
+		)
+		matchRepository, eventWatcher = exploreRepository, exploreRepository
+		outboxStore, deadLetterStore = exploreRepository, exploreRepository
+
+		checker := healthcheck.NewChecker(
+			logger,
+			healthServer,
+			func(ctx context.Context) error {
+				return mongoClient.Ping(ctx, readpref.Primary())
+			},
+			cfg.Health.Interval,
+			cfg.Health.Timeout,
+			pb.ExploreService_ServiceDesc.ServiceName,
+		)
+
+		go checker.Run(ctx)
+	default:
+		return fmt.Errorf("unknown database driver %q", cfg.Database.Driver)
+	}
+
+	var deadLetterReplayer api.DeadLetterReplayer
+
+	if cfg.Outbox.Enabled {
+		var (
+			publisher outbox.Publisher
+			relayOpts []outbox.RelayOption
+		)
+
+		switch cfg.Outbox.Publisher {
+		case outbox.PublisherStdout:
+			publisher = outbox.NewWriterPublisher(os.Stdout)
+		case outbox.PublisherFile:
+			file, err := os.OpenFile(cfg.Outbox.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+			if err != nil {
+				return fmt.Errorf("opening outbox file: %w", err)
+			}
+
+			defer func() {
+				if err = file.Close(); err != nil {
+					logger.Error("failed closing outbox file", slog.Any("error", err))
+				}
+			}()
+
+			publisher = outbox.NewWriterPublisher(file)
+		case outbox.PublisherWebhook:
+			webhookPublisher := outbox.NewWebhookPublisher(
+				logger,
+				&http.Client{Timeout: cfg.Outbox.Webhook.Timeout},
+				deadLetterStore,
+				cfg.WebhookEndpoints(),
+				[]byte(cfg.Outbox.Webhook.Secret),
+				cfg.Outbox.Webhook.MaxAttempts,
+			)
+			publisher, deadLetterReplayer = webhookPublisher, webhookPublisher
+			relayOpts = append(
+				relayOpts,
+				outbox.WithBackoff(cfg.Outbox.Webhook.InitialBackoff, cfg.Outbox.Webhook.MaxBackoff),
+			)
+		default:
+			return fmt.Errorf("unknown outbox publisher %q", cfg.Outbox.Publisher)
+		}
+
+		if serviceMetrics != nil {
+			relayOpts = append(relayOpts, outbox.WithObserver(serviceMetrics))
+		}
+
+		relay := outbox.NewRelay(
+			logger,
+			outboxStore,
+			publisher,
+			cfg.Outbox.Interval,
+			cfg.Outbox.LeaseDuration,
+			cfg.Outbox.BatchSize,
+			relayOpts...,
+		)
+		relayed := make(chan struct{})
+
+		go func() {
+			relay.Run(ctx)
+			close(relayed)
+		}()
+
+		// the relay is waited for before the deferred calls registered earlier run, so the outbox file is closed only
+		// after the relay stopped writing to it
+		defer func() {
+			<-relayed
+		}()
+	}
+
+	// the metrics measure the database, so they are observed beneath the cache
+	if serviceMetrics != nil {
+		matchRepository = metrics.NewRepository(serviceMetrics, matchRepository)
+	}
+
+	if cfg.Redis.Enabled {
+		redisClient := redis.NewClient(&redis.Options{
+			Addr:         cfg.Redis.URI,
+			Password:     cfg.Redis.Password,
+			DB:           cfg.Redis.Database,
+			DialTimeout:  cfg.Redis.Timeout,
+			ReadTimeout:  cfg.Redis.Timeout,
+			WriteTimeout: cfg.Redis.Timeout,
+		})
+
+		defer func() {
+			if err = redisClient.Close(); err != nil {
+				logger.Error("failed closing redis client", slog.Any("error", err))
+			}
+		}()
+
+		matchRepository = cache.NewRepository(
+			logger,
+			redisClient,
+			matchRepository,
+			cfg.Redis.CountTTL,
+			cfg.Redis.PageTTL,
+		)
+	}
+
+	unaryInterceptors = append(unaryInterceptors, api.ValidationUnaryInterceptor)
+	streamInterceptors = append(streamInterceptors, api.ValidationStreamInterceptor)
+
+	opts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	}
 
 	grpcServer := grpc.NewServer(opts...)
 
-	exploreServer := api.NewExploreServer(logger, cfg, grpcServer, exploreRepository, cfg.PageSize)
+	exploreServer := api.NewExploreServer(
+		logger,
+		cfg,
+		grpcServer,
+		healthServer,
+		matchRepository,
+		eventWatcher,
+		deadLetterReplayer,
+		cursorCodec,
+		cfg.PageSize,
+	)
 	pb.RegisterExploreServiceServer(grpcServer, exploreServer)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
+	// the admin services do not authenticate the callers, so they are only served on their own port
+	if cfg.Admin.Enabled {
+		adminServer := grpc.NewServer(opts...)
+		pb.RegisterModerationServiceServer(adminServer, exploreServer)
+		pb.RegisterWebhookServiceServer(adminServer, exploreServer)
+
+		address := net.JoinHostPort(cfg.Server.Host, cfg.Admin.Port)
+
+		adminListener, err := net.Listen("tcp", address)
+		if err != nil {
+			return fmt.Errorf("listening on %s: %w", address, err)
+		}
+
+		adminCtx, stopAdmin := context.WithCancel(ctx)
+		adminServed := make(chan struct{})
+
+		go func() {
+			if err := exploreServer.ServeAdmin(adminCtx, adminServer, adminListener); err != nil {
+				logger.Error("failed serving admin services", slog.Any("error", err))
+			}
+
+			close(adminServed)
+		}()
+
+		defer func() {
+			stopAdmin()
+			<-adminServed
+		}()
+	}
+
+	watcher := config.NewWatcher(logger, cfg, loadConfig, func(cfg *config.Config) {
+		logLevel.Set(cfg.Level())
+		userIDHashing.SetEnabled(cfg.Logging.HashUserIDs)
+		loggingInterceptor.SetSuccessSampleRatio(cfg.Logging.SuccessSampleRatio)
+		exploreServer.Reconfigure(cfg)
+	})
+
+	go watcher.Run(ctx)
+
+	if err = exploreServer.Run(ctx); err != nil {
+		return fmt.Errorf("running explore server: %w", err)
+	}
 
-	exploreServer.Run()
+	return nil
 }
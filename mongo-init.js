@@ -1,2 +1,30 @@
 db = db.getSiblingDB('db')
-db.createCollection('matches')
\ No newline at end of file
+db.createCollection('matches')
+db.matches.createIndex({ actorUserID: 1, recipientUserID: 1 }, { unique: true })
+db.matches.createIndex({ recipientUserID: 1, updatedAt: -1, actorUserID: -1 })
+db.matches.createIndex({ recipientUserID: 1, decision: -1, updatedAt: -1, actorUserID: -1 })
+db.createCollection('superLikes')
+db.superLikes.createIndex({ actorUserID: 1, day: 1 }, { unique: true })
+db.superLikes.createIndex({ expiresAt: 1 }, { expireAfterSeconds: 0 })
+db.createCollection('decisions')
+db.decisions.createIndex({ actorUserID: 1, decidedAt: -1 })
+db.createCollection('blocks')
+db.blocks.createIndex({ blockerUserID: 1, blockedUserID: 1 }, { unique: true })
+db.blocks.createIndex({ blockedUserID: 1, blockerUserID: 1 })
+db.blocks.createIndex({ blockerUserID: 1, createdAt: -1, blockedUserID: 1 })
+db.createCollection('reports')
+db.reports.createIndex({ state: 1, createdAt: 1, _id: 1 })
+db.reports.createIndex({ createdAt: 1, _id: 1 })
+db.reports.createIndex({ reportedUserID: 1, state: 1, reporterUserID: 1 })
+db.createCollection('reportedUsers')
+db.createCollection('events')
+db.events.createIndex({ at: 1 }, { expireAfterSeconds: 604800 })
+db.createCollection('outbox')
+db.outbox.createIndex({ pairKey: 1, sequence: 1 }, { unique: true })
+db.outbox.createIndex({ occurredAt: 1, _id: 1 }, { partialFilterExpression: { publishedAt: { $exists: false } } })
+db.outbox.createIndex({ publishedAt: 1 }, { expireAfterSeconds: 604800 })
+db.createCollection('outboxSequences')
+db.createCollection('outboxLeases')
+db.createCollection('deadLetters')
+db.deadLetters.createIndex({ "event.occurredAt": 1, "event.sequence": 1, _id: 1 })
+db.deadLetters.createIndex({ "event.pairKey": 1, endpoint: 1, "event.sequence": 1 })
@@ -9,8 +9,12 @@ import (
 	"github.com/stretchr/testify/suite"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/PatrykPasterny/dating-engine/tests/common"
+	pb "github.com/PatrykPasterny/dating-engine/tests/definition"
 	"github.com/PatrykPasterny/dating-engine/tests/model"
 )
 
@@ -339,3 +343,35 @@ func (s *apiTestSuite) getMatch(ctx context.Context, actorID, recipientID string
 
 	return &match, nil
 }
+
+// matchUsers makes the first user like the second one and then the second user like the first one back.
+func (s *apiTestSuite) matchUsers(firstID, secondID string) {
+	client := pb.NewExploreServiceClient(s.GrpcClient)
+
+	for _, putRequest := range []*pb.PutDecisionRequest{
+		{ActorUserId: firstID, RecipientUserId: secondID, LikedRecipient: true},
+		{ActorUserId: secondID, RecipientUserId: firstID, LikedRecipient: true},
+	} {
+		if _, err := client.PutDecision(context.Background(), putRequest); err != nil {
+			s.T().Fatalf("failed putting decision on user: %v", err)
+		}
+	}
+}
+
+func (s *apiTestSuite) requireFieldViolation(err error, field string) {
+	st, ok := status.FromError(err)
+	s.Require().True(ok, "expected grpc status error, got %v", err)
+	s.Require().Equal(codes.InvalidArgument, st.Code())
+
+	var violations []string
+
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.GetFieldViolations() {
+				violations = append(violations, violation.GetField())
+			}
+		}
+	}
+
+	s.Require().Contains(violations, field)
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/PatrykPasterny/dating-engine/tests/definition"
+)
+
+func (s *apiTestSuite) TestSuccessfullyBlockUser() {
+	client := pb.NewExploreServiceClient(s.GrpcClient)
+
+	blockerID, blockedID := uuid.NewString(), uuid.NewString()
+
+	s.matchUsers(blockerID, blockedID)
+
+	_, err := client.BlockUser(context.Background(), &pb.BlockUserRequest{
+		UserId:        blockerID,
+		BlockedUserId: blockedID,
+	})
+	if err != nil {
+		s.T().Fatalf("failed blocking user: %v", err)
+	}
+
+	for _, pair := range [][2]string{{blockerID, blockedID}, {blockedID, blockerID}} {
+		match, err := s.getMatch(context.Background(), pair[0], pair[1])
+		if err != nil {
+			s.T().Fatalf("failed getting match of blocked user: %v", err)
+		}
+
+		s.Equal(match.Matched, false)
+		s.Require().NotNil(match.Unmatch)
+		s.Equal(match.Unmatch.UserID, blockerID)
+		s.Equal(match.Unmatch.Reason, "blocked")
+
+		count, err := client.CountLikedYou(context.Background(), &pb.CountLikedYouRequest{
+			RecipientUserId: pair[0],
+		})
+		if err != nil {
+			s.T().Fatalf("failed counting users that liked the blocked user: %v", err)
+		}
+
+		s.Zero(count.Count)
+
+		_, err = client.PutDecision(context.Background(), &pb.PutDecisionRequest{
+			ActorUserId:     pair[0],
+			RecipientUserId: pair[1],
+			LikedRecipient:  true,
+		})
+		s.Equal(codes.PermissionDenied, status.Code(err))
+	}
+
+	response, err := client.ListBlocked(context.Background(), &pb.ListBlockedRequest{
+		UserId: blockerID,
+	})
+	if err != nil {
+		s.T().Fatalf("failed listing blocked users: %v", err)
+	}
+
+	s.Require().Len(response.BlockedUsers, 1)
+	s.Equal(response.BlockedUsers[0].UserId, blockedID)
+}
+
+func (s *apiTestSuite) TestSuccessfullyUnblockUser() {
+	client := pb.NewExploreServiceClient(s.GrpcClient)
+
+	blockerID, blockedID := uuid.NewString(), uuid.NewString()
+
+	_, err := client.PutDecision(context.Background(), &pb.PutDecisionRequest{
+		ActorUserId:     blockedID,
+		RecipientUserId: blockerID,
+		LikedRecipient:  true,
+	})
+	if err != nil {
+		s.T().Fatalf("failed putting decision on user: %v", err)
+	}
+
+	_, err = client.BlockUser(context.Background(), &pb.BlockUserRequest{
+		UserId:        blockerID,
+		BlockedUserId: blockedID,
+	})
+	if err != nil {
+		s.T().Fatalf("failed blocking user: %v", err)
+	}
+
+	_, err = client.UnblockUser(context.Background(), &pb.UnblockUserRequest{
+		UserId:        blockerID,
+		BlockedUserId: blockedID,
+	})
+	if err != nil {
+		s.T().Fatalf("failed unblocking user: %v", err)
+	}
+
+	response, err := client.ListLikedYou(context.Background(), &pb.ListLikedYouRequest{
+		RecipientUserId: blockerID,
+	})
+	if err != nil {
+		s.T().Fatalf("failed listing users that liked the user: %v", err)
+	}
+
+	s.Require().Len(response.Likers, 1)
+	s.Equal(response.Likers[0].ActorId, blockedID)
+
+	_, err = client.UnblockUser(context.Background(), &pb.UnblockUserRequest{
+		UserId:        blockerID,
+		BlockedUserId: blockedID,
+	})
+	s.Equal(codes.NotFound, status.Code(err))
+}
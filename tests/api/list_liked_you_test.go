@@ -2,6 +2,9 @@ package api
 
 import (
 	"context"
+	"time"
+
+	"github.com/google/uuid"
 
 	pb "github.com/PatrykPasterny/dating-engine/tests/definition"
 )
@@ -39,3 +42,133 @@ func (s *apiTestSuite) TestSuccessfullyGetLikedYou() {
 
 	s.Equal(responseLength, s.expectedUserLiked)
 }
+
+func (s *apiTestSuite) TestSuccessfullyGetLikedYouWithLikeTime() {
+	client := pb.NewExploreServiceClient(s.GrpcClient)
+
+	recipientUserID, err := uuid.NewRandom()
+	if err != nil {
+		s.T().Fatalf("failed generating new recipientUserID: %v", err)
+	}
+
+	putRequest := pb.PutDecisionRequest{
+		ActorUserId:     s.userID,
+		RecipientUserId: recipientUserID.String(),
+		LikedRecipient:  true,
+	}
+
+	likedBefore := time.Now().Unix()
+
+	if _, err = client.PutDecision(context.Background(), &putRequest); err != nil {
+		s.T().Fatalf("failed putting decision on user: %v", err)
+	}
+
+	request := pb.ListLikedYouRequest{
+		RecipientUserId: recipientUserID.String(),
+	}
+
+	response, err := client.ListLikedYou(context.Background(), &request)
+	if err != nil {
+		s.T().Fatalf("failed getting list of users that liked the user: %v", err)
+	}
+
+	s.Len(response.GetLikers(), 1)
+	s.Equal(response.GetLikers()[0].GetActorId(), s.userID)
+	s.GreaterOrEqual(response.GetLikers()[0].GetUnixTimestamp(), uint64(likedBefore))
+	s.LessOrEqual(response.GetLikers()[0].GetUnixTimestamp(), uint64(time.Now().Unix()))
+}
+
+func (s *apiTestSuite) TestSuccessfullyGetLikedYouNewestFirst() {
+	const likers = 3
+
+	client := pb.NewExploreServiceClient(s.GrpcClient)
+
+	recipientUserID, err := uuid.NewRandom()
+	if err != nil {
+		s.T().Fatalf("failed generating new recipientUserID: %v", err)
+	}
+
+	actorUserIDs := make([]string, 0, likers)
+
+	for range likers {
+		actorUserID, err := uuid.NewRandom()
+		if err != nil {
+			s.T().Fatalf("failed generating new actorUserID: %v", err)
+		}
+
+		putRequest := pb.PutDecisionRequest{
+			ActorUserId:     actorUserID.String(),
+			RecipientUserId: recipientUserID.String(),
+			LikedRecipient:  true,
+		}
+
+		if _, err = client.PutDecision(context.Background(), &putRequest); err != nil {
+			s.T().Fatalf("failed putting decision on user: %v", err)
+		}
+
+		actorUserIDs = append([]string{actorUserID.String()}, actorUserIDs...)
+
+		// make sure the likes are not stored with the same millisecond
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	request := pb.ListLikedYouRequest{
+		RecipientUserId: recipientUserID.String(),
+	}
+
+	response, err := client.ListLikedYou(context.Background(), &request)
+	if err != nil {
+		s.T().Fatalf("failed getting list of users that liked the user: %v", err)
+	}
+
+	likerIDs := make([]string, 0, len(response.GetLikers()))
+
+	for _, liker := range response.GetLikers() {
+		likerIDs = append(likerIDs, liker.GetActorId())
+	}
+
+	s.Equal(likerIDs, actorUserIDs)
+}
+
+func (s *apiTestSuite) TestFailToGetLikedYouWithInvalidPaginationToken() {
+	client := pb.NewExploreServiceClient(s.GrpcClient)
+
+	request := pb.ListLikedYouRequest{
+		RecipientUserId: s.userID,
+	}
+
+	response, err := client.ListLikedYou(context.Background(), &request)
+	if err != nil {
+		s.T().Fatalf("failed getting list of users that liked the user: %v", err)
+	}
+
+	validToken := response.GetNextPaginationToken()
+	s.NotEmpty(validToken)
+
+	// replace a character in the middle of the token, which no longer matches its signature
+	middle := len(validToken) / 2
+
+	replacement := "A"
+	if validToken[middle:middle+1] == replacement {
+		replacement = "B"
+	}
+
+	invalidTokens := []string{
+		uuid.Nil.String(),
+		"not a token",
+		validToken[:middle] + replacement + validToken[middle+1:],
+	}
+
+	for _, invalidToken := range invalidTokens {
+		request = pb.ListLikedYouRequest{
+			RecipientUserId: s.userID,
+			PaginationToken: &invalidToken,
+		}
+
+		_, err = client.ListLikedYou(context.Background(), &request)
+		s.requireFieldViolation(err, "pagination_token")
+
+		_, err = client.ListNewLikedYou(context.Background(), &request)
+		s.requireFieldViolation(err, "pagination_token")
+	}
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	pb "github.com/PatrykPasterny/dating-engine/tests/definition"
+)
+
+func (s *apiTestSuite) TestSuccessfullyGetLikedYouWithPageSize() {
+	pageSize := uint32(7)
+
+	client := pb.NewExploreServiceClient(s.GrpcClient)
+
+	request := pb.ListLikedYouRequest{
+		RecipientUserId: s.userID,
+		PageSize:        &pageSize,
+	}
+
+	responseLength := 0
+
+	for {
+		response, err := client.ListLikedYou(context.Background(), &request)
+		if err != nil {
+			s.T().Fatalf("failed getting list of users that liked the user: %v", err)
+		}
+
+		if len(response.GetLikers()) == 0 {
+			break
+		}
+
+		s.LessOrEqual(len(response.GetLikers()), int(pageSize))
+
+		responseLength += len(response.GetLikers())
+		request.PaginationToken = response.NextPaginationToken
+	}
+
+	s.Equal(responseLength, s.expectedUserLiked)
+}
+
+func (s *apiTestSuite) TestSuccessfullyGetNewLikedYouWithClampedPageSize() {
+	pageSize := uint32(1_000_000)
+
+	client := pb.NewExploreServiceClient(s.GrpcClient)
+
+	request := pb.ListLikedYouRequest{
+		RecipientUserId: s.userID,
+		PageSize:        &pageSize,
+	}
+
+	response, err := client.ListNewLikedYou(context.Background(), &request)
+	if err != nil {
+		s.T().Fatalf("failed getting list of new users that liked the user: %v", err)
+	}
+
+	s.Equal(len(response.GetLikers()), s.expectedUserLiked-s.expectedMutualLikes)
+}
+
+func (s *apiTestSuite) TestSuccessfullyGetLikedYouSortedByActor() {
+	client := pb.NewExploreServiceClient(s.GrpcClient)
+
+	request := pb.ListLikedYouRequest{
+		RecipientUserId: s.userID,
+		SortBy:          pb.SortBy_SORT_BY_ACTOR,
+		SortOrder:       pb.SortOrder_SORT_ORDER_DESCENDING,
+	}
+
+	var likerIDs []string
+
+	for {
+		response, err := client.ListLikedYou(context.Background(), &request)
+		if err != nil {
+			s.T().Fatalf("failed getting list of users that liked the user: %v", err)
+		}
+
+		if len(response.GetLikers()) == 0 {
+			break
+		}
+
+		for _, liker := range response.GetLikers() {
+			likerIDs = append(likerIDs, liker.GetActorId())
+		}
+
+		request.PaginationToken = response.NextPaginationToken
+	}
+
+	s.Len(likerIDs, s.expectedUserLiked)
+	s.True(slices.IsSortedFunc(likerIDs, func(a, b string) int {
+		return strings.Compare(b, a)
+	}))
+}
+
+func (s *apiTestSuite) TestFailToGetLikedYouWithInvalidSort() {
+	client := pb.NewExploreServiceClient(s.GrpcClient)
+
+	response, err := client.ListLikedYou(context.Background(), &pb.ListLikedYouRequest{
+		RecipientUserId: s.userID,
+		SortBy:          pb.SortBy_SORT_BY_TIME,
+	})
+	if err != nil {
+		s.T().Fatalf("failed getting list of users that liked the user: %v", err)
+	}
+
+	invalidRequests := []struct {
+		field   string
+		request *pb.ListLikedYouRequest
+	}{
+		{
+			field: "sort_by",
+			request: &pb.ListLikedYouRequest{
+				RecipientUserId: s.userID,
+				SortBy:          pb.SortBy(42),
+			},
+		},
+		{
+			field: "sort_order",
+			request: &pb.ListLikedYouRequest{
+				RecipientUserId: s.userID,
+				SortOrder:       pb.SortOrder(42),
+			},
+		},
+		{
+			field: "sort_by",
+			request: &pb.ListLikedYouRequest{
+				RecipientUserId: s.userID,
+				PaginationToken: response.NextPaginationToken,
+				SortBy:          pb.SortBy_SORT_BY_ACTOR,
+			},
+		},
+	}
+
+	for _, invalidRequest := range invalidRequests {
+		_, err = client.ListLikedYou(context.Background(), invalidRequest.request)
+		s.requireFieldViolation(err, invalidRequest.field)
+
+		_, err = client.ListNewLikedYou(context.Background(), invalidRequest.request)
+		s.requireFieldViolation(err, invalidRequest.field)
+	}
+}
@@ -2,6 +2,9 @@ package api
 
 import (
 	"context"
+	"sync"
+
+	"github.com/google/uuid"
 
 	pb "github.com/PatrykPasterny/dating-engine/tests/definition"
 )
@@ -113,3 +116,184 @@ func (s *apiTestSuite) TestSuccessfullyPutDecisionOnDislikedRecipient() {
 	s.Equal(recipientSideMatch.Liked, true)
 	s.Equal(putResponse.MutualLikes, false)
 }
+
+func (s *apiTestSuite) TestSuccessfullyPutFirstDecisionOnUser() {
+	client := pb.NewExploreServiceClient(s.GrpcClient)
+
+	actorUserID, err := uuid.NewRandom()
+	if err != nil {
+		s.T().Fatalf("failed generating new actorUserID: %v", err)
+	}
+
+	recipientUserID, err := uuid.NewRandom()
+	if err != nil {
+		s.T().Fatalf("failed generating new recipientUserID: %v", err)
+	}
+
+	putRequest := pb.PutDecisionRequest{
+		ActorUserId:     actorUserID.String(),
+		RecipientUserId: recipientUserID.String(),
+		LikedRecipient:  true,
+	}
+
+	putResponse, err := client.PutDecision(context.Background(), &putRequest)
+	if err != nil {
+		s.T().Fatalf("failed putting first decision on user: %v", err)
+	}
+
+	actorSideMatch, err := s.getMatch(context.Background(), putRequest.ActorUserId, putRequest.RecipientUserId)
+	if err != nil {
+		s.T().Fatalf("failed getting match for user as an actor: %v", err)
+	}
+
+	recipientSideMatch, err := s.getMatch(context.Background(), putRequest.RecipientUserId, putRequest.ActorUserId)
+	if err != nil {
+		s.T().Fatalf("failed getting match for user as a recipient: %v", err)
+	}
+
+	s.Equal(actorSideMatch.Liked, true)
+	s.Equal(actorSideMatch.Matched, false)
+	s.Equal(recipientSideMatch.Liked, false)
+	s.Equal(recipientSideMatch.Matched, false)
+	s.Equal(putResponse.MutualLikes, false)
+	s.False(actorSideMatch.CreatedAt.IsZero())
+	s.Equal(actorSideMatch.CreatedAt, actorSideMatch.UpdatedAt)
+	s.True(actorSideMatch.MatchedAt.IsZero())
+
+	putRequest = pb.PutDecisionRequest{
+		ActorUserId:     recipientUserID.String(),
+		RecipientUserId: actorUserID.String(),
+		LikedRecipient:  true,
+	}
+
+	putResponse, err = client.PutDecision(context.Background(), &putRequest)
+	if err != nil {
+		s.T().Fatalf("failed putting first decision on user that liked the user: %v", err)
+	}
+
+	actorSideMatch, err = s.getMatch(context.Background(), putRequest.ActorUserId, putRequest.RecipientUserId)
+	if err != nil {
+		s.T().Fatalf("failed getting match for user as an actor: %v", err)
+	}
+
+	recipientSideMatch, err = s.getMatch(context.Background(), putRequest.RecipientUserId, putRequest.ActorUserId)
+	if err != nil {
+		s.T().Fatalf("failed getting match for user as a recipient: %v", err)
+	}
+
+	s.Equal(actorSideMatch.Liked, true)
+	s.Equal(actorSideMatch.Matched, true)
+	s.Equal(recipientSideMatch.Liked, true)
+	s.Equal(recipientSideMatch.Matched, true)
+	s.Equal(putResponse.MutualLikes, true)
+	s.False(actorSideMatch.MatchedAt.IsZero())
+	s.Equal(actorSideMatch.MatchedAt, recipientSideMatch.MatchedAt)
+}
+
+func (s *apiTestSuite) TestSuccessfullyPutConcurrentMutualDecisions() {
+	const attempts = 10
+
+	client := pb.NewExploreServiceClient(s.GrpcClient)
+
+	for range attempts {
+		firstUserID, err := uuid.NewRandom()
+		if err != nil {
+			s.T().Fatalf("failed generating new firstUserID: %v", err)
+		}
+
+		secondUserID, err := uuid.NewRandom()
+		if err != nil {
+			s.T().Fatalf("failed generating new secondUserID: %v", err)
+		}
+
+		putRequests := []*pb.PutDecisionRequest{
+			{
+				ActorUserId:     firstUserID.String(),
+				RecipientUserId: secondUserID.String(),
+				LikedRecipient:  true,
+			},
+			{
+				ActorUserId:     secondUserID.String(),
+				RecipientUserId: firstUserID.String(),
+				LikedRecipient:  true,
+			},
+		}
+
+		putResponses := make([]*pb.PutDecisionResponse, len(putRequests))
+		putErrors := make([]error, len(putRequests))
+		start := make(chan struct{})
+		wg := sync.WaitGroup{}
+
+		for i := range putRequests {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				<-start
+
+				putResponses[i], putErrors[i] = client.PutDecision(context.Background(), putRequests[i])
+			}()
+		}
+
+		close(start)
+		wg.Wait()
+
+		mutualLikes := 0
+
+		for i := range putRequests {
+			if putErrors[i] != nil {
+				s.T().Fatalf("failed putting concurrent decision on user: %v", putErrors[i])
+			}
+
+			if putResponses[i].MutualLikes {
+				mutualLikes++
+			}
+
+			match, err := s.getMatch(context.Background(), putRequests[i].ActorUserId, putRequests[i].RecipientUserId)
+			if err != nil {
+				s.T().Fatalf("failed getting match for user as an actor: %v", err)
+			}
+
+			s.Equal(match.Liked, true)
+			s.Equal(match.Matched, true)
+		}
+
+		// decisions are serialized, so only the one committed second can see the mutual like
+		s.Equal(mutualLikes, 1)
+	}
+}
+
+func (s *apiTestSuite) TestSuccessfullyPutSuperLikeDecision() {
+	client := pb.NewExploreServiceClient(s.GrpcClient)
+
+	putRequest := pb.PutDecisionRequest{
+		ActorUserId:     uuid.NewString(),
+		RecipientUserId: uuid.NewString(),
+		Decision:        pb.Decision_DECISION_SUPER_LIKE,
+	}
+
+	putResponse, err := client.PutDecision(context.Background(), &putRequest)
+	if err != nil {
+		s.T().Fatalf("failed putting super like decision on user: %v", err)
+	}
+
+	actorSideMatch, err := s.getMatch(context.Background(), putRequest.ActorUserId, putRequest.RecipientUserId)
+	if err != nil {
+		s.T().Fatalf("failed getting match for user as an actor: %v", err)
+	}
+
+	s.Equal(actorSideMatch.Liked, true)
+	s.Equal(actorSideMatch.Decision, int(pb.Decision_DECISION_SUPER_LIKE))
+	s.Equal(putResponse.MutualLikes, false)
+
+	listResponse, err := client.ListNewLikedYou(context.Background(), &pb.ListLikedYouRequest{
+		RecipientUserId: putRequest.RecipientUserId,
+	})
+	if err != nil {
+		s.T().Fatalf("failed listing new likers of the super liked user: %v", err)
+	}
+
+	s.Len(listResponse.Likers, 1)
+	s.Equal(listResponse.Likers[0].SuperLiked, true)
+}
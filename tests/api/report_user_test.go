@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/PatrykPasterny/dating-engine/tests/definition"
+)
+
+func (s *apiTestSuite) TestSuccessfullyReportUser() {
+	client := pb.NewExploreServiceClient(s.GrpcClient)
+	moderation := pb.NewModerationServiceClient(s.AdminGrpcClient)
+
+	reportedID, likedID := uuid.NewString(), uuid.NewString()
+
+	_, err := client.PutDecision(context.Background(), &pb.PutDecisionRequest{
+		ActorUserId:     reportedID,
+		RecipientUserId: likedID,
+		LikedRecipient:  true,
+	})
+	if err != nil {
+		s.T().Fatalf("failed putting decision on user: %v", err)
+	}
+
+	// the service hides the users reported by three users by default
+	reportIDs := make(map[string]bool)
+
+	for range 3 {
+		response, err := client.ReportUser(context.Background(), &pb.ReportUserRequest{
+			ReporterUserId: uuid.NewString(),
+			ReportedUserId: reportedID,
+			Reason:         pb.ReportReason_REPORT_REASON_SPAM,
+			Details:        "sends the same link to everyone",
+			EvidenceRefs:   []string{"message:1", "message:2"},
+		})
+		if err != nil {
+			s.T().Fatalf("failed reporting user: %v", err)
+		}
+
+		reportIDs[response.ReportId] = true
+	}
+
+	likers, err := client.ListLikedYou(context.Background(), &pb.ListLikedYouRequest{
+		RecipientUserId: likedID,
+	})
+	if err != nil {
+		s.T().Fatalf("failed listing users that liked the user: %v", err)
+	}
+
+	s.Empty(likers.Likers)
+
+	request := &pb.ListReportsRequest{State: pb.ReportState_REPORT_STATE_OPEN}
+
+	var reports []*pb.Report
+
+	for {
+		response, err := moderation.ListReports(context.Background(), request)
+		if err != nil {
+			s.T().Fatalf("failed listing reports: %v", err)
+		}
+
+		if len(response.Reports) == 0 {
+			break
+		}
+
+		for _, report := range response.Reports {
+			if reportIDs[report.Id] {
+				reports = append(reports, report)
+			}
+		}
+
+		request.PaginationToken = response.NextPaginationToken
+	}
+
+	s.Require().Len(reports, 3)
+
+	for _, report := range reports {
+		s.Equal(report.ReportedUserId, reportedID)
+		s.Equal(report.Reason, pb.ReportReason_REPORT_REASON_SPAM)
+		s.Equal(report.EvidenceRefs, []string{"message:1", "message:2"})
+
+		_, err = client.PutDecision(context.Background(), &pb.PutDecisionRequest{
+			ActorUserId:     reportedID,
+			RecipientUserId: report.ReporterUserId,
+			LikedRecipient:  true,
+		})
+		s.Equal(codes.PermissionDenied, status.Code(err))
+	}
+
+	resolved, err := moderation.ResolveReport(context.Background(), &pb.ResolveReportRequest{
+		ReportId: reports[0].Id,
+		State:    pb.ReportState_REPORT_STATE_DISMISSED,
+	})
+	if err != nil {
+		s.T().Fatalf("failed resolving report: %v", err)
+	}
+
+	s.Equal(resolved.Report.State, pb.ReportState_REPORT_STATE_DISMISSED)
+
+	likers, err = client.ListLikedYou(context.Background(), &pb.ListLikedYouRequest{
+		RecipientUserId: likedID,
+	})
+	if err != nil {
+		s.T().Fatalf("failed listing users that liked the user: %v", err)
+	}
+
+	s.Require().Len(likers.Likers, 1)
+	s.Equal(likers.Likers[0].ActorId, reportedID)
+}
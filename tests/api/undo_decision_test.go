@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/PatrykPasterny/dating-engine/tests/definition"
+)
+
+func (s *apiTestSuite) TestSuccessfullyUndoDecision() {
+	client := pb.NewExploreServiceClient(s.GrpcClient)
+
+	actorID, recipientID := uuid.NewString(), uuid.NewString()
+
+	s.matchUsers(recipientID, actorID)
+
+	undoResponse, err := client.UndoDecision(context.Background(), &pb.UndoDecisionRequest{
+		ActorUserId: actorID,
+	})
+	if err != nil {
+		s.T().Fatalf("failed undoing decision of user: %v", err)
+	}
+
+	s.Equal(undoResponse.RecipientUserId, recipientID)
+	s.Equal(undoResponse.UndoneDecision, pb.Decision_DECISION_LIKE)
+	s.Equal(undoResponse.RestoredDecision, pb.Decision_DECISION_UNSPECIFIED)
+
+	actorSideMatch, err := s.getMatch(context.Background(), actorID, recipientID)
+	if err != nil {
+		s.T().Fatalf("failed getting match for user as an actor: %v", err)
+	}
+
+	recipientSideMatch, err := s.getMatch(context.Background(), recipientID, actorID)
+	if err != nil {
+		s.T().Fatalf("failed getting match for user as a recipient: %v", err)
+	}
+
+	s.Equal(actorSideMatch.Liked, false)
+	s.Equal(actorSideMatch.Matched, false)
+	s.Equal(recipientSideMatch.Liked, true)
+	s.Equal(recipientSideMatch.Matched, false)
+
+	_, err = client.UndoDecision(context.Background(), &pb.UndoDecisionRequest{
+		ActorUserId: actorID,
+	})
+	s.Equal(status.Code(err), codes.NotFound)
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	pb "github.com/PatrykPasterny/dating-engine/tests/definition"
+)
+
+func (s *apiTestSuite) TestSuccessfullyUnmatch() {
+	client := pb.NewExploreServiceClient(s.GrpcClient)
+
+	firstID, secondID := uuid.NewString(), uuid.NewString()
+
+	s.matchUsers(firstID, secondID)
+
+	_, err := client.Unmatch(context.Background(), &pb.UnmatchRequest{
+		UserId:        firstID,
+		MatchedUserId: secondID,
+		Reason:        pb.UnmatchReason_UNMATCH_REASON_MET_SOMEONE,
+	})
+	if err != nil {
+		s.T().Fatalf("failed unmatching users: %v", err)
+	}
+
+	for _, pair := range [][2]string{{firstID, secondID}, {secondID, firstID}} {
+		match, err := s.getMatch(context.Background(), pair[0], pair[1])
+		if err != nil {
+			s.T().Fatalf("failed getting match of unmatched user: %v", err)
+		}
+
+		s.Equal(match.Matched, false)
+		s.True(match.MatchedAt.IsZero())
+		s.Require().NotNil(match.Unmatch)
+		s.Equal(match.Unmatch.UserID, firstID)
+		s.Equal(match.Unmatch.Reason, "met_someone")
+		s.False(match.Unmatch.At.IsZero())
+
+		response, err := client.ListLikedYou(context.Background(), &pb.ListLikedYouRequest{
+			RecipientUserId: pair[0],
+		})
+		if err != nil {
+			s.T().Fatalf("failed listing users that liked the unmatched user: %v", err)
+		}
+
+		s.Empty(response.Likers)
+	}
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"strings"
+
+	pb "github.com/PatrykPasterny/dating-engine/tests/definition"
+)
+
+func (s *apiTestSuite) TestFailToListLikedYouWithInvalidRecipient() {
+	client := pb.NewExploreServiceClient(s.GrpcClient)
+
+	invalidRecipientIDs := []string{
+		"",
+		"not-a-uuid",
+		strings.ToUpper(s.userID),
+		"{" + s.userID + "}",
+	}
+
+	for _, recipientID := range invalidRecipientIDs {
+		request := pb.ListLikedYouRequest{
+			RecipientUserId: recipientID,
+		}
+
+		_, err := client.ListLikedYou(context.Background(), &request)
+		s.requireFieldViolation(err, "recipient_user_id")
+
+		_, err = client.ListNewLikedYou(context.Background(), &request)
+		s.requireFieldViolation(err, "recipient_user_id")
+
+		_, err = client.CountLikedYou(context.Background(), &pb.CountLikedYouRequest{
+			RecipientUserId: recipientID,
+		})
+		s.requireFieldViolation(err, "recipient_user_id")
+	}
+}
+
+func (s *apiTestSuite) TestFailToListLikedYouWithMalformedPaginationToken() {
+	client := pb.NewExploreServiceClient(s.GrpcClient)
+
+	invalidTokens := []string{
+		"not base64!",
+		strings.Repeat("A", 1024),
+	}
+
+	for _, invalidToken := range invalidTokens {
+		request := pb.ListLikedYouRequest{
+			RecipientUserId: s.userID,
+			PaginationToken: &invalidToken,
+		}
+
+		_, err := client.ListLikedYou(context.Background(), &request)
+		s.requireFieldViolation(err, "pagination_token")
+	}
+}
+
+func (s *apiTestSuite) TestFailToPutDecisionWithInvalidUsers() {
+	client := pb.NewExploreServiceClient(s.GrpcClient)
+
+	_, err := client.PutDecision(context.Background(), &pb.PutDecisionRequest{
+		ActorUserId:     "",
+		RecipientUserId: s.userID,
+		LikedRecipient:  true,
+	})
+	s.requireFieldViolation(err, "actor_user_id")
+
+	_, err = client.PutDecision(context.Background(), &pb.PutDecisionRequest{
+		ActorUserId:     s.userID,
+		RecipientUserId: "not-a-uuid",
+		LikedRecipient:  true,
+	})
+	s.requireFieldViolation(err, "recipient_user_id")
+
+	_, err = client.PutDecision(context.Background(), &pb.PutDecisionRequest{
+		ActorUserId:     s.userID,
+		RecipientUserId: s.userID,
+		LikedRecipient:  true,
+	})
+	s.requireFieldViolation(err, "recipient_user_id")
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	pb "github.com/PatrykPasterny/dating-engine/tests/definition"
+)
+
+func (s *apiTestSuite) TestSuccessfullyWatchLikes() {
+	client := pb.NewExploreServiceClient(s.GrpcClient)
+
+	userID, likerID := uuid.NewString(), uuid.NewString()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stream, err := client.WatchLikes(ctx, &pb.WatchLikesRequest{UserId: userID})
+	if err != nil {
+		s.T().Fatalf("failed watching likes: %v", err)
+	}
+
+	if _, err = stream.Header(); err != nil {
+		s.T().Fatalf("failed receiving headers of watched likes: %v", err)
+	}
+
+	s.matchUsers(likerID, userID)
+
+	like, err := stream.Recv()
+	if err != nil {
+		s.T().Fatalf("failed receiving like: %v", err)
+	}
+
+	s.Equal(like.Kind, pb.LikeEventKind_LIKE_EVENT_KIND_LIKE)
+	s.Equal(like.OtherUserId, likerID)
+
+	match, err := stream.Recv()
+	if err != nil {
+		s.T().Fatalf("failed receiving match: %v", err)
+	}
+
+	s.Equal(match.Kind, pb.LikeEventKind_LIKE_EVENT_KIND_MATCH)
+	s.Equal(match.OtherUserId, likerID)
+
+	// the stream resumed after the like starts with the match, which was made in the meantime
+	resumed, err := client.WatchLikes(ctx, &pb.WatchLikesRequest{
+		UserId:      userID,
+		ResumeToken: &like.ResumeToken,
+	})
+	if err != nil {
+		s.T().Fatalf("failed resuming watched likes: %v", err)
+	}
+
+	event, err := resumed.Recv()
+	if err != nil {
+		s.T().Fatalf("failed receiving resumed match: %v", err)
+	}
+
+	s.Equal(event.Kind, pb.LikeEventKind_LIKE_EVENT_KIND_MATCH)
+	s.Equal(event.OtherUserId, likerID)
+}
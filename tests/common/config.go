@@ -9,6 +9,7 @@ type Config struct {
 	DatabaseName       string
 	DatabaseCollection string
 	BaseURL            string
+	AdminURL           string
 }
 
 func NewConfig() *Config {
@@ -17,5 +18,6 @@ func NewConfig() *Config {
 		DatabaseName:       os.Getenv("DATABASE_NAME"),
 		DatabaseCollection: os.Getenv("DATABASE_COLLECTION"),
 		BaseURL:            os.Getenv("BASE_URL"),
+		AdminURL:           os.Getenv("ADMIN_URL"),
 	}
 }
@@ -19,6 +19,8 @@ type TestSuite struct {
 	Collection *mongo.Collection
 	Logger     *slog.Logger
 	GrpcClient *grpc.ClientConn
+	// AdminGrpcClient is connected to the admin port serving the moderation and webhook services.
+	AdminGrpcClient *grpc.ClientConn
 }
 
 func NewTestSuite() (*TestSuite, error) {
@@ -42,6 +44,11 @@ func NewTestSuite() (*TestSuite, error) {
 		return nil, fmt.Errorf("creating new grpc dbClient: %w", err)
 	}
 
+	adminConn, err := grpc.NewClient(cfg.AdminURL, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating new admin grpc client: %w", err)
+	}
+
 	clientOpts := options.Client().ApplyURI(cfg.DatabaseURI)
 
 	dbClient, err := mongo.Connect(context.Background(), clientOpts)
@@ -52,10 +59,11 @@ func NewTestSuite() (*TestSuite, error) {
 	collection := dbClient.Database(cfg.DatabaseName).Collection(cfg.DatabaseCollection)
 
 	ts := &TestSuite{
-		dbClient:   dbClient,
-		Collection: collection,
-		Logger:     logger,
-		GrpcClient: conn,
+		dbClient:        dbClient,
+		Collection:      collection,
+		Logger:          logger,
+		GrpcClient:      conn,
+		AdminGrpcClient: adminConn,
 	}
 
 	return ts, nil
@@ -69,13 +77,19 @@ func (ts *TestSuite) TearDownSuite() {
 	ts.Logger.Info("tearing down the test suite")
 
 	if err := ts.dbClient.Disconnect(context.Background()); err != nil {
-		ts.Logger.Error("failed disconnecting from mongoDB instance", err)
+		ts.Logger.Error("failed disconnecting from mongoDB instance", slog.Any("error", err))
 
 		return
 	}
 
 	if err := ts.GrpcClient.Close(); err != nil {
-		ts.Logger.Error("failed closing grpc client instance", err)
+		ts.Logger.Error("failed closing grpc client instance", slog.Any("error", err))
+
+		return
+	}
+
+	if err := ts.AdminGrpcClient.Close(); err != nil {
+		ts.Logger.Error("failed closing admin grpc client instance", slog.Any("error", err))
 
 		return
 	}
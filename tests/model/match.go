@@ -1,8 +1,21 @@
 package model
 
+import "time"
+
 type Match struct {
-	RecipientUserID string `json:"recipientUserID" bson:"recipientUserID"`
-	ActorUserID     string `json:"actorUserID" bson:"actorUserID"`
-	Liked           bool   `json:"liked" bson:"liked"`
-	Matched         bool   `json:"matched" bson:"matched"`
+	RecipientUserID string    `json:"recipientUserID" bson:"recipientUserID"`
+	ActorUserID     string    `json:"actorUserID" bson:"actorUserID"`
+	Liked           bool      `json:"liked" bson:"liked"`
+	Matched         bool      `json:"matched" bson:"matched"`
+	Decision        int       `json:"decision" bson:"decision,omitempty"`
+	CreatedAt       time.Time `json:"createdAt" bson:"createdAt,omitempty"`
+	UpdatedAt       time.Time `json:"updatedAt" bson:"updatedAt,omitempty"`
+	MatchedAt       time.Time `json:"matchedAt" bson:"matchedAt,omitempty"`
+	Unmatch         *Unmatch  `json:"unmatch" bson:"unmatch,omitempty"`
+}
+
+type Unmatch struct {
+	UserID string    `json:"userID" bson:"userID"`
+	Reason string    `json:"reason" bson:"reason"`
+	At     time.Time `json:"at" bson:"at"`
 }
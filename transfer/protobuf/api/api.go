@@ -2,34 +2,45 @@ package api
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
+	"github.com/PatrykPasterny/dating-engine/internal/logging"
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+	"github.com/PatrykPasterny/dating-engine/internal/repository"
 	pb "github.com/PatrykPasterny/dating-engine/transfer/protobuf/definition"
-
-	"github.com/google/uuid"
 )
 
+var unmatchReasons = map[pb.UnmatchReason]model.UnmatchReason{
+	pb.UnmatchReason_UNMATCH_REASON_NO_LONGER_INTERESTED:    model.UnmatchReasonNoLongerInterested,
+	pb.UnmatchReason_UNMATCH_REASON_NO_CONNECTION:           model.UnmatchReasonNoConnection,
+	pb.UnmatchReason_UNMATCH_REASON_INAPPROPRIATE_BEHAVIOUR: model.UnmatchReasonInappropriateBehaviour,
+	pb.UnmatchReason_UNMATCH_REASON_MET_SOMEONE:             model.UnmatchReasonMetSomeone,
+	pb.UnmatchReason_UNMATCH_REASON_OTHER:                   model.UnmatchReasonOther,
+}
+
 func (es *ExploreServer) ListLikedYou(
 	ctx context.Context,
 	request *pb.ListLikedYouRequest,
 ) (*pb.ListLikedYouResponse, error) {
-	loggerWithFields := es.logger.With(
-		slog.String("recipient_id", request.RecipientUserId),
+	loggerWithFields := es.requestLogger(ctx).With(
+		logging.UserID("recipient_id", request.RecipientUserId),
 	)
 
-	loggerWithFields.Info("retrieving list of all users that liked the user")
+	loggerWithFields.DebugContext(ctx, "retrieving list of all users that liked the user")
 
-	paginationToken := uuid.Nil.String()
+	page, err := es.newPage(request)
+	if err != nil {
+		loggerWithFields.WarnContext(ctx, "received invalid pagination of the list", slog.Any("error", err))
 
-	if request.PaginationToken != nil {
-		paginationToken = *request.PaginationToken
+		return nil, err
 	}
 
-	likedYouList, err := es.matchRepository.GetLikedUser(ctx, request.RecipientUserId, paginationToken, es.pageSize)
+	likedYouList, err := es.matchRepository.GetLikedUser(ctx, request.RecipientUserId, page)
 	if err != nil {
-		loggerWithFields.Error("failed to get all users that liked the user", err)
+		loggerWithFields.ErrorContext(ctx, "failed to get all users that liked the user", slog.Any("error", err))
 
-		return nil, err
+		return nil, toStatus(err)
 	}
 
 	var response pb.ListLikedYouResponse
@@ -37,18 +48,19 @@ func (es *ExploreServer) ListLikedYou(
 	response.Likers = make([]*pb.ListLikedYouResponse_Liker, 0, len(likedYouList))
 
 	for i := range likedYouList {
-		if i == len(likedYouList)-1 {
-			response.NextPaginationToken = &likedYouList[i].ActorUserID
-		}
+		response.Likers = append(response.Likers, newLiker(&likedYouList[i]))
+	}
 
-		liker := &pb.ListLikedYouResponse_Liker{
-			ActorId: likedYouList[i].ActorUserID,
-		}
+	if len(likedYouList) > 0 {
+		response.NextPaginationToken, err = es.nextPaginationToken(page, &likedYouList[len(likedYouList)-1])
+		if err != nil {
+			loggerWithFields.ErrorContext(ctx, "failed to create next pagination token", slog.Any("error", err))
 
-		response.Likers = append(response.Likers, liker)
+			return nil, toStatus(err)
+		}
 	}
 
-	loggerWithFields.Info("successfully retrieved list of users that liked the user")
+	loggerWithFields.DebugContext(ctx, "successfully retrieved list of users that liked the user")
 
 	return &response, nil
 }
@@ -57,23 +69,27 @@ func (es *ExploreServer) ListNewLikedYou(
 	ctx context.Context,
 	request *pb.ListLikedYouRequest,
 ) (*pb.ListLikedYouResponse, error) {
-	loggerWithFields := es.logger.With(
-		slog.String("recipient_id", request.RecipientUserId),
+	loggerWithFields := es.requestLogger(ctx).With(
+		logging.UserID("recipient_id", request.RecipientUserId),
 	)
 
-	loggerWithFields.Info("retrieving list of new users that liked the user")
+	loggerWithFields.DebugContext(ctx, "retrieving list of new users that liked the user")
 
-	paginationToken := uuid.Nil.String()
+	page, err := es.newPage(request)
+	if err != nil {
+		loggerWithFields.WarnContext(ctx, "received invalid pagination of the list", slog.Any("error", err))
 
-	if request.PaginationToken != nil {
-		paginationToken = *request.PaginationToken
+		return nil, err
 	}
 
-	likedYouList, err := es.matchRepository.GetNewLikedUser(ctx, request.RecipientUserId, paginationToken, es.pageSize)
+	// the super likes are highlighted to the recipient until they are answered
+	page.SuperLikesFirst = true
+
+	likedYouList, err := es.matchRepository.GetNewLikedUser(ctx, request.RecipientUserId, page)
 	if err != nil {
-		loggerWithFields.Error("failed to get new users that liked the user", err)
+		loggerWithFields.ErrorContext(ctx, "failed to get new users that liked the user", slog.Any("error", err))
 
-		return nil, err
+		return nil, toStatus(err)
 	}
 
 	var response pb.ListLikedYouResponse
@@ -81,18 +97,19 @@ func (es *ExploreServer) ListNewLikedYou(
 	response.Likers = make([]*pb.ListLikedYouResponse_Liker, 0, len(likedYouList))
 
 	for i := range likedYouList {
-		if i == len(likedYouList)-1 {
-			response.NextPaginationToken = &likedYouList[i].ActorUserID
-		}
+		response.Likers = append(response.Likers, newLiker(&likedYouList[i]))
+	}
 
-		liker := &pb.ListLikedYouResponse_Liker{
-			ActorId: likedYouList[i].ActorUserID,
-		}
+	if len(likedYouList) > 0 {
+		response.NextPaginationToken, err = es.nextPaginationToken(page, &likedYouList[len(likedYouList)-1])
+		if err != nil {
+			loggerWithFields.ErrorContext(ctx, "failed to create next pagination token", slog.Any("error", err))
 
-		response.Likers = append(response.Likers, liker)
+			return nil, toStatus(err)
+		}
 	}
 
-	loggerWithFields.Info("successfully retrieved list of new users that liked the user")
+	loggerWithFields.DebugContext(ctx, "successfully retrieved list of new users that liked the user")
 
 	return &response, nil
 }
@@ -101,56 +118,280 @@ func (es *ExploreServer) CountLikedYou(
 	ctx context.Context,
 	request *pb.CountLikedYouRequest,
 ) (*pb.CountLikedYouResponse, error) {
-	loggerWithFields := es.logger.With(
-		slog.String("recipient_id", request.RecipientUserId),
+	loggerWithFields := es.requestLogger(ctx).With(
+		logging.UserID("recipient_id", request.RecipientUserId),
 	)
 
-	loggerWithFields.Info("counting users that liked the user")
+	loggerWithFields.DebugContext(ctx, "counting users that liked the user")
 
 	count, err := es.matchRepository.CountLikedUser(ctx, request.RecipientUserId)
 	if err != nil {
-		loggerWithFields.Error("failed to count users that liked the user", err)
+		loggerWithFields.ErrorContext(ctx, "failed to count users that liked the user", slog.Any("error", err))
 
-		return nil, err
+		return nil, toStatus(err)
 	}
 
 	response := pb.CountLikedYouResponse{
 		Count: count,
 	}
 
-	loggerWithFields.Info("successfully counted users that liked the user")
+	loggerWithFields.DebugContext(ctx, "successfully counted users that liked the user")
 
-	return &response, err
+	return &response, nil
 }
 
 func (es *ExploreServer) PutDecision(
 	ctx context.Context,
 	request *pb.PutDecisionRequest,
 ) (*pb.PutDecisionResponse, error) {
-	loggerWithFields := es.logger.With(
-		slog.String("actor_id", request.ActorUserId),
-		slog.String("recipient_id", request.RecipientUserId),
+	loggerWithFields := es.requestLogger(ctx).With(
+		logging.UserID("actor_id", request.ActorUserId),
+		logging.UserID("recipient_id", request.RecipientUserId),
 	)
 
-	loggerWithFields.Info("applying new decision of the user")
+	decision := decisionOf(request)
 
-	mutualLikes, err := es.matchRepository.MakeDecision(
+	loggerWithFields.DebugContext(ctx, "applying new decision of the user", slog.String("decision", decision.String()))
+
+	result, err := es.matchRepository.MakeDecision(
 		ctx,
 		request.ActorUserId,
 		request.RecipientUserId,
-		request.LikedRecipient,
+		decision,
+		es.decisionSettings.Load().superLikeLimit,
 	)
+	if errors.Is(err, repository.ErrLimitExceeded) {
+		loggerWithFields.InfoContext(ctx, "user has no super likes left for the day")
+
+		return nil, toStatus(err)
+	}
+
+	if errors.Is(err, repository.ErrForbidden) {
+		loggerWithFields.InfoContext(ctx, "users blocked each other", slog.Any("error", err))
+
+		return nil, toStatus(err)
+	}
+
 	if err != nil {
-		loggerWithFields.Error("failed to make decision on user", err)
+		loggerWithFields.ErrorContext(ctx, "failed to make decision on user", slog.Any("error", err))
 
-		return nil, err
+		return nil, toStatus(err)
 	}
 
 	response := pb.PutDecisionResponse{
-		MutualLikes: mutualLikes,
+		MutualLikes: result.MutualLikes,
+	}
+
+	loggerWithFields.DebugContext(ctx, "successfully made new decision of user")
+
+	return &response, nil
+}
+
+func (es *ExploreServer) UndoDecision(
+	ctx context.Context,
+	request *pb.UndoDecisionRequest,
+) (*pb.UndoDecisionResponse, error) {
+	loggerWithFields := es.requestLogger(ctx).With(
+		logging.UserID("actor_id", request.ActorUserId),
+	)
+
+	loggerWithFields.DebugContext(ctx, "undoing latest decision of the user")
+
+	record, err := es.matchRepository.UndoDecision(ctx, request.ActorUserId, es.decisionSettings.Load().undoWindow)
+	if errors.Is(err, repository.ErrNotFound) {
+		loggerWithFields.InfoContext(ctx, "user has no decision to undo", slog.Any("error", err))
+
+		return nil, toStatus(err)
 	}
 
-	loggerWithFields.Info("successfully made new decision of user")
+	if errors.Is(err, repository.ErrForbidden) {
+		loggerWithFields.InfoContext(ctx, "users of decision to undo blocked each other", slog.Any("error", err))
+
+		return nil, toStatus(err)
+	}
+
+	if err != nil {
+		loggerWithFields.ErrorContext(ctx, "failed to undo decision of user", slog.Any("error", err))
+
+		return nil, toStatus(err)
+	}
+
+	response := pb.UndoDecisionResponse{
+		RecipientUserId:  record.RecipientUserID,
+		UndoneDecision:   toDecision(record.Decision, record.Decision.Liked()),
+		RestoredDecision: pb.Decision_DECISION_UNSPECIFIED,
+	}
+
+	if record.Previous != nil {
+		response.RestoredDecision = toDecision(record.Previous.Decision, record.Previous.Liked)
+	}
+
+	loggerWithFields.DebugContext(
+		ctx,
+		"successfully undone decision of user",
+		logging.UserID("recipient_id", record.RecipientUserID),
+	)
+
+	return &response, nil
+}
+
+func (es *ExploreServer) Unmatch(
+	ctx context.Context,
+	request *pb.UnmatchRequest,
+) (*pb.UnmatchResponse, error) {
+	loggerWithFields := es.requestLogger(ctx).With(
+		logging.UserID("user_id", request.UserId),
+		logging.UserID("matched_user_id", request.MatchedUserId),
+	)
+
+	reason := unmatchReasons[request.Reason]
+
+	loggerWithFields.DebugContext(ctx, "unmatching users", slog.String("reason", string(reason)))
+
+	err := es.matchRepository.Unmatch(ctx, request.UserId, request.MatchedUserId, reason)
+	if errors.Is(err, repository.ErrNotFound) {
+		loggerWithFields.InfoContext(ctx, "users to unmatch are not matched", slog.Any("error", err))
+
+		return nil, toStatus(err)
+	}
+
+	if err != nil {
+		loggerWithFields.ErrorContext(ctx, "failed to unmatch users", slog.Any("error", err))
+
+		return nil, toStatus(err)
+	}
+
+	loggerWithFields.DebugContext(ctx, "successfully unmatched users")
+
+	return &pb.UnmatchResponse{}, nil
+}
+
+func (es *ExploreServer) BlockUser(
+	ctx context.Context,
+	request *pb.BlockUserRequest,
+) (*pb.BlockUserResponse, error) {
+	loggerWithFields := es.requestLogger(ctx).With(
+		logging.UserID("user_id", request.UserId),
+		logging.UserID("blocked_user_id", request.BlockedUserId),
+	)
+
+	loggerWithFields.DebugContext(ctx, "blocking user")
+
+	if err := es.matchRepository.BlockUser(ctx, request.UserId, request.BlockedUserId); err != nil {
+		loggerWithFields.ErrorContext(ctx, "failed to block user", slog.Any("error", err))
+
+		return nil, toStatus(err)
+	}
+
+	loggerWithFields.DebugContext(ctx, "successfully blocked user")
+
+	return &pb.BlockUserResponse{}, nil
+}
+
+func (es *ExploreServer) UnblockUser(
+	ctx context.Context,
+	request *pb.UnblockUserRequest,
+) (*pb.UnblockUserResponse, error) {
+	loggerWithFields := es.requestLogger(ctx).With(
+		logging.UserID("user_id", request.UserId),
+		logging.UserID("blocked_user_id", request.BlockedUserId),
+	)
+
+	loggerWithFields.DebugContext(ctx, "unblocking user")
+
+	err := es.matchRepository.UnblockUser(ctx, request.UserId, request.BlockedUserId)
+	if errors.Is(err, repository.ErrNotFound) {
+		loggerWithFields.InfoContext(ctx, "user to unblock is not blocked", slog.Any("error", err))
+
+		return nil, toStatus(err)
+	}
+
+	if err != nil {
+		loggerWithFields.ErrorContext(ctx, "failed to unblock user", slog.Any("error", err))
+
+		return nil, toStatus(err)
+	}
+
+	loggerWithFields.DebugContext(ctx, "successfully unblocked user")
+
+	return &pb.UnblockUserResponse{}, nil
+}
+
+func (es *ExploreServer) ListBlocked(
+	ctx context.Context,
+	request *pb.ListBlockedRequest,
+) (*pb.ListBlockedResponse, error) {
+	loggerWithFields := es.requestLogger(ctx).With(
+		logging.UserID("user_id", request.UserId),
+	)
+
+	loggerWithFields.DebugContext(ctx, "listing users blocked by the user")
+
+	blocks, err := es.matchRepository.GetBlockedUsers(ctx, request.UserId)
+	if err != nil {
+		loggerWithFields.ErrorContext(ctx, "failed to list users blocked by the user", slog.Any("error", err))
+
+		return nil, toStatus(err)
+	}
+
+	response := pb.ListBlockedResponse{
+		BlockedUsers: make([]*pb.ListBlockedResponse_BlockedUser, 0, len(blocks)),
+	}
+
+	for i := range blocks {
+		response.BlockedUsers = append(response.BlockedUsers, &pb.ListBlockedResponse_BlockedUser{
+			UserId:        blocks[i].BlockedUserID,
+			UnixTimestamp: uint64(blocks[i].CreatedAt.Unix()),
+		})
+	}
+
+	loggerWithFields.DebugContext(ctx, "successfully listed users blocked by the user")
+
+	return &response, nil
+}
+
+// decisionOf returns the decision of the request, falling back to liked_recipient for the clients that do not set
+// the decision.
+func decisionOf(request *pb.PutDecisionRequest) model.Decision {
+	switch request.Decision {
+	case pb.Decision_DECISION_PASS:
+		return model.DecisionPass
+	case pb.Decision_DECISION_LIKE:
+		return model.DecisionLike
+	case pb.Decision_DECISION_SUPER_LIKE:
+		return model.DecisionSuperLike
+	}
+
+	if request.LikedRecipient {
+		return model.DecisionLike
+	}
+
+	return model.DecisionPass
+}
+
+// toDecision converts the stored decision, the matches stored before the decisions were have only the liked flag.
+func toDecision(decision model.Decision, liked bool) pb.Decision {
+	switch {
+	case decision == model.DecisionSuperLike:
+		return pb.Decision_DECISION_SUPER_LIKE
+	case decision == model.DecisionLike, decision == model.DecisionNone && liked:
+		return pb.Decision_DECISION_LIKE
+	default:
+		return pb.Decision_DECISION_PASS
+	}
+}
+
+// newLiker converts the match into the liker, using the time of the latest decision of the actor as the time
+// of the like, because only likes are listed.
+func newLiker(match *model.Match) *pb.ListLikedYouResponse_Liker {
+	liker := &pb.ListLikedYouResponse_Liker{
+		ActorId:    match.ActorUserID,
+		SuperLiked: match.SuperLiked(),
+	}
+
+	if !match.UpdatedAt.IsZero() {
+		liker.UnixTimestamp = uint64(match.UpdatedAt.Unix())
+	}
 
-	return &response, err
+	return liker
 }
@@ -0,0 +1,806 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+	"github.com/PatrykPasterny/dating-engine/internal/pagination"
+	"github.com/PatrykPasterny/dating-engine/internal/repository"
+	"github.com/PatrykPasterny/dating-engine/transfer/protobuf/api/apitest"
+	pb "github.com/PatrykPasterny/dating-engine/transfer/protobuf/definition"
+)
+
+type apiTestSuite struct {
+	suite.Suite
+	harness *apitest.Harness
+}
+
+func TestApi(t *testing.T) {
+	suite.Run(t, new(apiTestSuite))
+}
+
+func (s *apiTestSuite) SetupTest() {
+	s.harness = apitest.NewHarness(s.T(), repository.NewMemoryRepository(), apitest.WithPageSize(3, 5))
+}
+
+func (s *apiTestSuite) TestSuccessfullyListLikedYou() {
+	recipientID := s.harness.NewUser()
+	likerIDs := s.harness.LikedBy(recipientID, 4)
+
+	matchedID := s.harness.NewUser()
+	s.harness.Match(matchedID, recipientID)
+	s.harness.Pass(s.harness.NewUser(), recipientID)
+
+	likers := s.listAll(s.harness.Client.ListLikedYou, &pb.ListLikedYouRequest{
+		RecipientUserId: recipientID,
+	})
+
+	s.ElementsMatch(append(likerIDs, matchedID), s.actorIDs(likers))
+
+	for _, liker := range likers {
+		s.NotZero(liker.GetUnixTimestamp())
+		s.LessOrEqual(liker.GetUnixTimestamp(), uint64(time.Now().Unix()))
+	}
+}
+
+func (s *apiTestSuite) TestSuccessfullyListNewLikedYou() {
+	recipientID := s.harness.NewUser()
+	likerIDs := s.harness.LikedBy(recipientID, 4)
+
+	s.harness.Match(s.harness.NewUser(), recipientID)
+	s.harness.Like(recipientID, likerIDs[0])
+
+	likers := s.listAll(s.harness.Client.ListNewLikedYou, &pb.ListLikedYouRequest{
+		RecipientUserId: recipientID,
+	})
+
+	s.ElementsMatch(likerIDs[1:], s.actorIDs(likers))
+}
+
+func (s *apiTestSuite) TestSuccessfullyListLikedYouInRequestedOrder() {
+	recipientID := s.harness.NewUser()
+	likerIDs := s.harness.LikedBy(recipientID, 5)
+
+	pageSize := uint32(2)
+
+	likers := s.listAll(s.harness.Client.ListLikedYou, &pb.ListLikedYouRequest{
+		RecipientUserId: recipientID,
+		PageSize:        &pageSize,
+		SortBy:          pb.SortBy_SORT_BY_ACTOR,
+		SortOrder:       pb.SortOrder_SORT_ORDER_ASCENDING,
+	})
+
+	slices.Sort(likerIDs)
+	s.Equal(likerIDs, s.actorIDs(likers))
+}
+
+func (s *apiTestSuite) TestSuccessfullyListLikedYouWithClampedPageSize() {
+	recipientID := s.harness.NewUser()
+	s.harness.LikedBy(recipientID, 7)
+
+	pageSize := uint32(1000)
+
+	response, err := s.harness.Client.ListLikedYou(context.Background(), &pb.ListLikedYouRequest{
+		RecipientUserId: recipientID,
+		PageSize:        &pageSize,
+	})
+	s.Require().NoError(err)
+	s.Len(response.GetLikers(), int(s.harness.Config.MaxPageSize))
+}
+
+func (s *apiTestSuite) TestFailToListLikedYouWithAnotherUsersToken() {
+	recipientID := s.harness.NewUser()
+	s.harness.LikedBy(recipientID, 4)
+
+	response, err := s.harness.Client.ListLikedYou(context.Background(), &pb.ListLikedYouRequest{
+		RecipientUserId: recipientID,
+	})
+	s.Require().NoError(err)
+	s.Require().NotNil(response.NextPaginationToken)
+
+	_, err = s.harness.Client.ListLikedYou(context.Background(), &pb.ListLikedYouRequest{
+		RecipientUserId: s.harness.NewUser(),
+		PaginationToken: response.NextPaginationToken,
+	})
+	s.requireFieldViolation(err, "pagination_token")
+}
+
+func (s *apiTestSuite) TestSuccessfullyListLikedYouAfterReconfiguring() {
+	recipientID := s.harness.NewUser()
+	likerIDs := s.harness.LikedBy(recipientID, 4)
+
+	s.harness.Reconfigure(
+		apitest.WithPageSize(2, 2),
+		apitest.WithSort(pagination.SortByActor, pagination.Ascending),
+	)
+
+	response, err := s.harness.Client.ListLikedYou(context.Background(), &pb.ListLikedYouRequest{
+		RecipientUserId: recipientID,
+	})
+	s.Require().NoError(err)
+
+	slices.Sort(likerIDs)
+
+	s.Equal(likerIDs[:2], s.actorIDs(response.GetLikers()))
+}
+
+func (s *apiTestSuite) TestSuccessfullyCountLikedYou() {
+	recipientID := s.harness.NewUser()
+	s.harness.LikedBy(recipientID, 4)
+	s.harness.Match(s.harness.NewUser(), recipientID)
+	s.harness.Pass(s.harness.NewUser(), recipientID)
+
+	response, err := s.harness.Client.CountLikedYou(context.Background(), &pb.CountLikedYouRequest{
+		RecipientUserId: recipientID,
+	})
+	s.Require().NoError(err)
+	s.Equal(uint64(5), response.GetCount())
+}
+
+func (s *apiTestSuite) TestSuccessfullyPutDecision() {
+	firstID, secondID := s.harness.NewUser(), s.harness.NewUser()
+
+	response, err := s.harness.Client.PutDecision(context.Background(), &pb.PutDecisionRequest{
+		ActorUserId:     firstID,
+		RecipientUserId: secondID,
+		LikedRecipient:  true,
+	})
+	s.Require().NoError(err)
+	s.False(response.GetMutualLikes())
+
+	response, err = s.harness.Client.PutDecision(context.Background(), &pb.PutDecisionRequest{
+		ActorUserId:     secondID,
+		RecipientUserId: firstID,
+		LikedRecipient:  true,
+	})
+	s.Require().NoError(err)
+	s.True(response.GetMutualLikes())
+
+	response, err = s.harness.Client.PutDecision(context.Background(), &pb.PutDecisionRequest{
+		ActorUserId:     firstID,
+		RecipientUserId: secondID,
+		LikedRecipient:  false,
+	})
+	s.Require().NoError(err)
+	s.False(response.GetMutualLikes())
+
+	likers := s.listAll(s.harness.Client.ListNewLikedYou, &pb.ListLikedYouRequest{
+		RecipientUserId: firstID,
+	})
+	s.Equal([]string{secondID}, s.actorIDs(likers))
+}
+
+func (s *apiTestSuite) TestSuccessfullyListNewLikedYouWithSuperLikesFirst() {
+	recipientID := s.harness.NewUser()
+	likerIDs := s.harness.LikedBy(recipientID, 4)
+
+	superLikerID := s.harness.NewUser()
+	s.harness.SuperLike(superLikerID, recipientID)
+	likerIDs = append(likerIDs, s.harness.LikedBy(recipientID, 1)...)
+
+	likers := s.listAll(s.harness.Client.ListNewLikedYou, &pb.ListLikedYouRequest{
+		RecipientUserId: recipientID,
+		SortOrder:       pb.SortOrder_SORT_ORDER_ASCENDING,
+	})
+	s.Require().Len(likers, 6)
+	s.Equal(superLikerID, likers[0].GetActorId())
+	s.True(likers[0].GetSuperLiked())
+	s.ElementsMatch(likerIDs, s.actorIDs(likers[1:]))
+
+	for _, liker := range likers[1:] {
+		s.False(liker.GetSuperLiked())
+	}
+
+	// the list of all likers keeps its order, but still marks the super likes
+	likers = s.listAll(s.harness.Client.ListLikedYou, &pb.ListLikedYouRequest{
+		RecipientUserId: recipientID,
+	})
+	s.Require().Len(likers, 6)
+
+	for _, liker := range likers {
+		s.Equal(liker.GetActorId() == superLikerID, liker.GetSuperLiked())
+	}
+}
+
+func (s *apiTestSuite) TestFailToSuperLikeAboveDailyLimit() {
+	s.harness.Reconfigure(apitest.WithSuperLikeLimit(1))
+
+	actorID := s.harness.NewUser()
+
+	response, err := s.harness.Client.PutDecision(context.Background(), &pb.PutDecisionRequest{
+		ActorUserId:     actorID,
+		RecipientUserId: s.harness.NewUser(),
+		Decision:        pb.Decision_DECISION_SUPER_LIKE,
+	})
+	s.Require().NoError(err)
+	s.False(response.GetMutualLikes())
+
+	recipientID := s.harness.NewUser()
+
+	_, err = s.harness.Client.PutDecision(context.Background(), &pb.PutDecisionRequest{
+		ActorUserId:     actorID,
+		RecipientUserId: recipientID,
+		Decision:        pb.Decision_DECISION_SUPER_LIKE,
+	})
+	s.Require().Equal(codes.ResourceExhausted, status.Code(err))
+
+	_, err = s.harness.Client.PutDecision(context.Background(), &pb.PutDecisionRequest{
+		ActorUserId:     actorID,
+		RecipientUserId: recipientID,
+		Decision:        pb.Decision_DECISION_LIKE,
+	})
+	s.Require().NoError(err)
+}
+
+func (s *apiTestSuite) TestSuccessfullyUndoDecision() {
+	actorID, recipientID := s.harness.NewUser(), s.harness.NewUser()
+	s.harness.Like(recipientID, actorID)
+	s.harness.Pass(actorID, recipientID)
+
+	response, err := s.harness.Client.PutDecision(context.Background(), &pb.PutDecisionRequest{
+		ActorUserId:     actorID,
+		RecipientUserId: recipientID,
+		Decision:        pb.Decision_DECISION_SUPER_LIKE,
+	})
+	s.Require().NoError(err)
+	s.True(response.GetMutualLikes())
+
+	undoResponse, err := s.harness.Client.UndoDecision(context.Background(), &pb.UndoDecisionRequest{
+		ActorUserId: actorID,
+	})
+	s.Require().NoError(err)
+	s.Equal(recipientID, undoResponse.GetRecipientUserId())
+	s.Equal(pb.Decision_DECISION_SUPER_LIKE, undoResponse.GetUndoneDecision())
+	s.Equal(pb.Decision_DECISION_PASS, undoResponse.GetRestoredDecision())
+
+	likers := s.listAll(s.harness.Client.ListNewLikedYou, &pb.ListLikedYouRequest{
+		RecipientUserId: actorID,
+	})
+	s.Equal([]string{recipientID}, s.actorIDs(likers))
+
+	countResponse, err := s.harness.Client.CountLikedYou(context.Background(), &pb.CountLikedYouRequest{
+		RecipientUserId: recipientID,
+	})
+	s.Require().NoError(err)
+	s.Zero(countResponse.GetCount())
+}
+
+func (s *apiTestSuite) TestFailToUndoDecisionOutsideWindow() {
+	actorID := s.harness.NewUser()
+
+	_, err := s.harness.Client.UndoDecision(context.Background(), &pb.UndoDecisionRequest{
+		ActorUserId: actorID,
+	})
+	s.Require().Equal(codes.NotFound, status.Code(err))
+
+	s.harness.Like(actorID, s.harness.NewUser())
+	s.harness.Reconfigure(apitest.WithUndoWindow(0))
+
+	_, err = s.harness.Client.UndoDecision(context.Background(), &pb.UndoDecisionRequest{
+		ActorUserId: actorID,
+	})
+	s.Require().Equal(codes.NotFound, status.Code(err))
+}
+
+func (s *apiTestSuite) TestSuccessfullyUnmatch() {
+	firstID, secondID := s.harness.NewUser(), s.harness.NewUser()
+	s.harness.Match(firstID, secondID)
+
+	_, err := s.harness.Client.Unmatch(context.Background(), &pb.UnmatchRequest{
+		UserId:        firstID,
+		MatchedUserId: secondID,
+		Reason:        pb.UnmatchReason_UNMATCH_REASON_NO_LONGER_INTERESTED,
+	})
+	s.Require().NoError(err)
+
+	for _, userID := range []string{firstID, secondID} {
+		likers := s.listAll(s.harness.Client.ListLikedYou, &pb.ListLikedYouRequest{
+			RecipientUserId: userID,
+		})
+		s.Empty(likers)
+	}
+
+	_, err = s.harness.Client.Unmatch(context.Background(), &pb.UnmatchRequest{
+		UserId:        secondID,
+		MatchedUserId: firstID,
+		Reason:        pb.UnmatchReason_UNMATCH_REASON_OTHER,
+	})
+	s.Require().Equal(codes.NotFound, status.Code(err))
+}
+
+func (s *apiTestSuite) TestSuccessfullyBlockUser() {
+	blockerID, blockedID := s.harness.NewUser(), s.harness.NewUser()
+	s.harness.Match(blockerID, blockedID)
+
+	_, err := s.harness.Client.BlockUser(context.Background(), &pb.BlockUserRequest{
+		UserId:        blockerID,
+		BlockedUserId: blockedID,
+	})
+	s.Require().NoError(err)
+
+	for _, userID := range []string{blockerID, blockedID} {
+		count, err := s.harness.Client.CountLikedYou(context.Background(), &pb.CountLikedYouRequest{
+			RecipientUserId: userID,
+		})
+		s.Require().NoError(err)
+		s.Zero(count.Count)
+	}
+
+	_, err = s.harness.Client.PutDecision(context.Background(), &pb.PutDecisionRequest{
+		ActorUserId:     blockedID,
+		RecipientUserId: blockerID,
+		Decision:        pb.Decision_DECISION_LIKE,
+	})
+	s.Require().Equal(codes.PermissionDenied, status.Code(err))
+
+	blocked, err := s.harness.Client.ListBlocked(context.Background(), &pb.ListBlockedRequest{
+		UserId: blockerID,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(blocked.BlockedUsers, 1)
+	s.Equal(blockedID, blocked.BlockedUsers[0].UserId)
+	s.NotZero(blocked.BlockedUsers[0].UnixTimestamp)
+}
+
+func (s *apiTestSuite) TestSuccessfullyUnblockUser() {
+	blockerID, blockedID := s.harness.NewUser(), s.harness.NewUser()
+	s.harness.Like(blockedID, blockerID)
+
+	_, err := s.harness.Client.BlockUser(context.Background(), &pb.BlockUserRequest{
+		UserId:        blockerID,
+		BlockedUserId: blockedID,
+	})
+	s.Require().NoError(err)
+
+	_, err = s.harness.Client.UnblockUser(context.Background(), &pb.UnblockUserRequest{
+		UserId:        blockerID,
+		BlockedUserId: blockedID,
+	})
+	s.Require().NoError(err)
+
+	likers := s.listAll(s.harness.Client.ListLikedYou, &pb.ListLikedYouRequest{
+		RecipientUserId: blockerID,
+	})
+	s.Equal([]string{blockedID}, s.actorIDs(likers))
+
+	blocked, err := s.harness.Client.ListBlocked(context.Background(), &pb.ListBlockedRequest{
+		UserId: blockerID,
+	})
+	s.Require().NoError(err)
+	s.Empty(blocked.BlockedUsers)
+
+	_, err = s.harness.Client.UnblockUser(context.Background(), &pb.UnblockUserRequest{
+		UserId:        blockerID,
+		BlockedUserId: blockedID,
+	})
+	s.Require().Equal(codes.NotFound, status.Code(err))
+}
+
+func (s *apiTestSuite) TestSuccessfullyReportUser() {
+	s.harness.Reconfigure(apitest.WithHideThreshold(2))
+
+	reportedID, likedID := s.harness.NewUser(), s.harness.NewUser()
+	reporterIDs := s.harness.NewUsers(2)
+	s.harness.Like(reportedID, likedID)
+
+	for i, reporterID := range reporterIDs {
+		response, err := s.harness.Client.ReportUser(context.Background(), &pb.ReportUserRequest{
+			ReporterUserId: reporterID,
+			ReportedUserId: reportedID,
+			Reason:         pb.ReportReason_REPORT_REASON_HARASSMENT,
+			Details:        "rude messages",
+			EvidenceRefs:   []string{"message:42"},
+		})
+		s.Require().NoError(err)
+		s.NotEmpty(response.ReportId)
+
+		likers := s.listAll(s.harness.Client.ListLikedYou, &pb.ListLikedYouRequest{
+			RecipientUserId: likedID,
+		})
+		s.Len(likers, 1-i, "the user is hidden only once reported by two users")
+	}
+
+	_, err := s.harness.Client.PutDecision(context.Background(), &pb.PutDecisionRequest{
+		ActorUserId:     reportedID,
+		RecipientUserId: reporterIDs[0],
+		Decision:        pb.Decision_DECISION_LIKE,
+	})
+	s.Require().Equal(codes.PermissionDenied, status.Code(err))
+}
+
+func (s *apiTestSuite) TestSuccessfullyListAndResolveReports() {
+	reportedID := s.harness.NewUser()
+
+	var reportIDs []string
+
+	for _, reporterID := range s.harness.NewUsers(3) {
+		response, err := s.harness.Client.ReportUser(context.Background(), &pb.ReportUserRequest{
+			ReporterUserId: reporterID,
+			ReportedUserId: reportedID,
+			Reason:         pb.ReportReason_REPORT_REASON_FAKE_PROFILE,
+		})
+		s.Require().NoError(err)
+
+		reportIDs = append(reportIDs, response.ReportId)
+	}
+
+	resolved, err := s.harness.Moderation.ResolveReport(context.Background(), &pb.ResolveReportRequest{
+		ReportId: reportIDs[1],
+		State:    pb.ReportState_REPORT_STATE_DISMISSED,
+		Note:     "a real profile",
+	})
+	s.Require().NoError(err)
+	s.Equal(reportIDs[1], resolved.Report.Id)
+	s.Equal(pb.ReportState_REPORT_STATE_DISMISSED, resolved.Report.State)
+	s.Equal(pb.ReportReason_REPORT_REASON_FAKE_PROFILE, resolved.Report.Reason)
+	s.Equal("a real profile", resolved.Report.ResolutionNote)
+	s.NotZero(resolved.Report.ResolvedUnixTimestamp)
+
+	_, err = s.harness.Moderation.ResolveReport(context.Background(), &pb.ResolveReportRequest{
+		ReportId: reportIDs[1],
+		State:    pb.ReportState_REPORT_STATE_ACTIONED,
+	})
+	s.Require().Equal(codes.NotFound, status.Code(err))
+
+	pageSize := uint32(1)
+	request := &pb.ListReportsRequest{
+		State:    pb.ReportState_REPORT_STATE_OPEN,
+		PageSize: &pageSize,
+	}
+
+	var openIDs []string
+
+	for {
+		response, err := s.harness.Moderation.ListReports(context.Background(), request)
+		s.Require().NoError(err)
+		s.Require().LessOrEqual(len(response.Reports), 1)
+
+		if len(response.Reports) == 0 {
+			break
+		}
+
+		s.Equal(pb.ReportState_REPORT_STATE_OPEN, response.Reports[0].State)
+		openIDs = append(openIDs, response.Reports[0].Id)
+		request.PaginationToken = response.NextPaginationToken
+	}
+
+	s.ElementsMatch([]string{reportIDs[0], reportIDs[2]}, openIDs)
+}
+
+func (s *apiTestSuite) TestFailToCallAdminServicesOnExplorePort() {
+	moderation := pb.NewModerationServiceClient(s.harness.Conn)
+
+	_, err := moderation.ListReports(context.Background(), &pb.ListReportsRequest{})
+	s.Require().Equal(codes.Unimplemented, status.Code(err))
+
+	webhooks := pb.NewWebhookServiceClient(s.harness.Conn)
+
+	_, err = webhooks.ReplayDeadLetters(context.Background(), &pb.ReplayDeadLettersRequest{})
+	s.Require().Equal(codes.Unimplemented, status.Code(err))
+}
+
+func (s *apiTestSuite) TestFailToListWithTokenOfAnotherList() {
+	recipientID := s.harness.NewUser()
+	s.harness.LikedBy(recipientID, 4)
+
+	for _, reporterID := range s.harness.NewUsers(4) {
+		_, err := s.harness.Client.ReportUser(context.Background(), &pb.ReportUserRequest{
+			ReporterUserId: reporterID,
+			ReportedUserId: recipientID,
+			Reason:         pb.ReportReason_REPORT_REASON_SPAM,
+		})
+		s.Require().NoError(err)
+	}
+
+	likers, err := s.harness.Client.ListLikedYou(context.Background(), &pb.ListLikedYouRequest{
+		RecipientUserId: recipientID,
+	})
+	s.Require().NoError(err)
+	s.Require().NotNil(likers.NextPaginationToken)
+
+	reports, err := s.harness.Moderation.ListReports(context.Background(), &pb.ListReportsRequest{
+		State: pb.ReportState_REPORT_STATE_OPEN,
+	})
+	s.Require().NoError(err)
+	s.Require().NotNil(reports.NextPaginationToken)
+
+	_, err = s.harness.Moderation.ListReports(context.Background(), &pb.ListReportsRequest{
+		PaginationToken: likers.NextPaginationToken,
+	})
+	s.requireFieldViolation(err, "pagination_token")
+
+	_, err = s.harness.Moderation.ListReports(context.Background(), &pb.ListReportsRequest{
+		State:           pb.ReportState_REPORT_STATE_DISMISSED,
+		PaginationToken: reports.NextPaginationToken,
+	})
+	s.requireFieldViolation(err, "state")
+
+	_, err = s.harness.Client.ListLikedYou(context.Background(), &pb.ListLikedYouRequest{
+		RecipientUserId: recipientID,
+		PaginationToken: reports.NextPaginationToken,
+	})
+	s.requireFieldViolation(err, "pagination_token")
+}
+
+func (s *apiTestSuite) TestSuccessfullyWatchLikes() {
+	userID, likerID := s.harness.NewUser(), s.harness.NewUser()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := s.harness.Client.WatchLikes(ctx, &pb.WatchLikesRequest{UserId: userID})
+	s.Require().NoError(err)
+
+	// the headers are received once the user is subscribed, so the like made afterwards is streamed
+	_, err = stream.Header()
+	s.Require().NoError(err)
+
+	s.harness.SuperLike(likerID, userID)
+
+	like, err := stream.Recv()
+	s.Require().NoError(err)
+	s.Equal(pb.LikeEventKind_LIKE_EVENT_KIND_LIKE, like.Kind)
+	s.Equal(likerID, like.OtherUserId)
+	s.True(like.SuperLiked)
+	s.NotZero(like.UnixTimestamp)
+	s.NotEmpty(like.ResumeToken)
+
+	s.harness.Like(userID, likerID)
+
+	match, err := stream.Recv()
+	s.Require().NoError(err)
+	s.Equal(pb.LikeEventKind_LIKE_EVENT_KIND_MATCH, match.Kind)
+	s.Equal(likerID, match.OtherUserId)
+
+	resumed, err := s.harness.Client.WatchLikes(ctx, &pb.WatchLikesRequest{
+		UserId:      userID,
+		ResumeToken: &like.ResumeToken,
+	})
+	s.Require().NoError(err)
+
+	event, err := resumed.Recv()
+	s.Require().NoError(err)
+	s.Equal(match.ResumeToken, event.ResumeToken)
+
+	unknownToken := "42"
+
+	unknown, err := s.harness.Client.WatchLikes(ctx, &pb.WatchLikesRequest{
+		UserId:      userID,
+		ResumeToken: &unknownToken,
+	})
+	s.Require().NoError(err)
+
+	_, err = unknown.Recv()
+	s.requireFieldViolation(err, "resume_token")
+}
+
+func (s *apiTestSuite) TestSuccessfullyReplayDeadLetters() {
+	var delivered atomic.Int64
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		delivered.Add(1)
+	}))
+	defer receiver.Close()
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	memoryRepository := repository.NewMemoryRepository()
+	harness := apitest.NewHarness(s.T(), memoryRepository, apitest.WithWebhook(receiver.URL, "secret"))
+	failedAt := time.Now().UTC().Add(-time.Hour)
+
+	deadLetters := []model.DeadLetter{
+		{
+			ID: harness.NewUser(),
+			Event: model.OutboxEvent{
+				ID:         harness.NewUser(),
+				Type:       model.OutboxEventMatchCreated,
+				OccurredAt: failedAt.Add(-time.Minute),
+			},
+			Endpoint: receiver.URL,
+			Attempts: 5,
+			FailedAt: failedAt,
+		},
+		{
+			ID: harness.NewUser(),
+			Event: model.OutboxEvent{
+				ID:         harness.NewUser(),
+				Type:       model.OutboxEventLikeCreated,
+				OccurredAt: failedAt,
+			},
+			Endpoint: failing.URL,
+			Attempts: 5,
+			FailedAt: failedAt.Add(time.Minute),
+		},
+	}
+
+	for _, deadLetter := range deadLetters {
+		s.Require().NoError(memoryRepository.SaveDeadLetter(context.Background(), deadLetter))
+	}
+
+	response, err := harness.Webhooks.ReplayDeadLetters(context.Background(), &pb.ReplayDeadLettersRequest{
+		DeadLetterIds: []string{deadLetters[1].ID, deadLetters[0].ID},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(response.Replays, 2)
+
+	s.Equal(deadLetters[0].ID, response.Replays[0].DeadLetterId)
+	s.Equal(deadLetters[0].Event.ID, response.Replays[0].EventId)
+	s.Equal("MatchCreated", response.Replays[0].EventType)
+	s.True(response.Replays[0].Delivered)
+	s.Equal(uint64(6), response.Replays[0].Attempts)
+	s.Empty(response.Replays[0].LastError)
+	s.Equal(int64(1), delivered.Load())
+
+	s.Equal(deadLetters[1].ID, response.Replays[1].DeadLetterId)
+	s.False(response.Replays[1].Delivered)
+	s.Equal(uint64(6), response.Replays[1].Attempts)
+	s.Equal("unexpected status 503", response.Replays[1].LastError)
+
+	// only the dead letter that failed again is left to replay
+	response, err = harness.Webhooks.ReplayDeadLetters(context.Background(), &pb.ReplayDeadLettersRequest{})
+	s.Require().NoError(err)
+	s.Require().Len(response.Replays, 1)
+	s.Equal(deadLetters[1].ID, response.Replays[0].DeadLetterId)
+
+	_, err = harness.Webhooks.ReplayDeadLetters(context.Background(), &pb.ReplayDeadLettersRequest{
+		DeadLetterIds: []string{deadLetters[0].ID},
+	})
+	s.Require().Equal(codes.NotFound, status.Code(err))
+}
+
+func (s *apiTestSuite) TestFailToReplayDeadLettersWithoutWebhook() {
+	_, err := s.harness.Webhooks.ReplayDeadLetters(context.Background(), &pb.ReplayDeadLettersRequest{})
+	s.Require().Equal(codes.FailedPrecondition, status.Code(err))
+}
+
+func (s *apiTestSuite) TestFailToCallWithInvalidRequest() {
+	userID := s.harness.NewUser()
+	invalidToken := "not a token"
+
+	_, err := s.harness.Client.ListLikedYou(context.Background(), &pb.ListLikedYouRequest{
+		RecipientUserId: "not-a-uuid",
+	})
+	s.requireFieldViolation(err, "recipient_user_id")
+
+	_, err = s.harness.Client.ListNewLikedYou(context.Background(), &pb.ListLikedYouRequest{
+		RecipientUserId: userID,
+		PaginationToken: &invalidToken,
+	})
+	s.requireFieldViolation(err, "pagination_token")
+
+	_, err = s.harness.Client.CountLikedYou(context.Background(), &pb.CountLikedYouRequest{})
+	s.requireFieldViolation(err, "recipient_user_id")
+
+	_, err = s.harness.Client.PutDecision(context.Background(), &pb.PutDecisionRequest{
+		ActorUserId:     userID,
+		RecipientUserId: userID,
+	})
+	s.requireFieldViolation(err, "recipient_user_id")
+
+	_, err = s.harness.Client.PutDecision(context.Background(), &pb.PutDecisionRequest{
+		ActorUserId:     userID,
+		RecipientUserId: s.harness.NewUser(),
+		Decision:        pb.Decision(42),
+	})
+	s.requireFieldViolation(err, "decision")
+
+	_, err = s.harness.Client.UndoDecision(context.Background(), &pb.UndoDecisionRequest{})
+	s.requireFieldViolation(err, "actor_user_id")
+
+	_, err = s.harness.Client.Unmatch(context.Background(), &pb.UnmatchRequest{
+		UserId:        userID,
+		MatchedUserId: s.harness.NewUser(),
+	})
+	s.requireFieldViolation(err, "reason")
+
+	_, err = s.harness.Client.BlockUser(context.Background(), &pb.BlockUserRequest{
+		UserId:        userID,
+		BlockedUserId: userID,
+	})
+	s.requireFieldViolation(err, "blocked_user_id")
+
+	_, err = s.harness.Client.UnblockUser(context.Background(), &pb.UnblockUserRequest{
+		BlockedUserId: userID,
+	})
+	s.requireFieldViolation(err, "user_id")
+
+	_, err = s.harness.Client.ListBlocked(context.Background(), &pb.ListBlockedRequest{})
+	s.requireFieldViolation(err, "user_id")
+
+	_, err = s.harness.Client.ReportUser(context.Background(), &pb.ReportUserRequest{
+		ReporterUserId: userID,
+		ReportedUserId: s.harness.NewUser(),
+		Reason:         pb.ReportReason_REPORT_REASON_SPAM,
+		EvidenceRefs:   []string{""},
+	})
+	s.requireFieldViolation(err, "evidence_refs[0]")
+
+	_, err = s.harness.Moderation.ListReports(context.Background(), &pb.ListReportsRequest{
+		State: pb.ReportState(42),
+	})
+	s.requireFieldViolation(err, "state")
+
+	_, err = s.harness.Moderation.ResolveReport(context.Background(), &pb.ResolveReportRequest{
+		ReportId: s.harness.NewUser(),
+		State:    pb.ReportState_REPORT_STATE_OPEN,
+	})
+	s.requireFieldViolation(err, "state")
+
+	_, err = s.harness.Webhooks.ReplayDeadLetters(context.Background(), &pb.ReplayDeadLettersRequest{
+		DeadLetterIds: []string{userID, userID},
+	})
+	s.requireFieldViolation(err, "dead_letter_ids[1]")
+
+	stream, err := s.harness.Client.WatchLikes(context.Background(), &pb.WatchLikesRequest{
+		UserId: "not-a-uuid",
+	})
+	s.Require().NoError(err)
+
+	_, err = stream.Recv()
+	s.requireFieldViolation(err, "user_id")
+}
+
+type listFunc func(
+	ctx context.Context,
+	request *pb.ListLikedYouRequest,
+	opts ...grpc.CallOption,
+) (*pb.ListLikedYouResponse, error)
+
+func (s *apiTestSuite) listAll(list listFunc, request *pb.ListLikedYouRequest) []*pb.ListLikedYouResponse_Liker {
+	var likers []*pb.ListLikedYouResponse_Liker
+
+	for {
+		response, err := list(context.Background(), request)
+		s.Require().NoError(err)
+
+		if len(response.GetLikers()) == 0 {
+			return likers
+		}
+
+		likers = append(likers, response.GetLikers()...)
+		request.PaginationToken = response.NextPaginationToken
+	}
+}
+
+func (s *apiTestSuite) actorIDs(likers []*pb.ListLikedYouResponse_Liker) []string {
+	actorIDs := make([]string, 0, len(likers))
+
+	for _, liker := range likers {
+		actorIDs = append(actorIDs, liker.GetActorId())
+	}
+
+	return actorIDs
+}
+
+func (s *apiTestSuite) requireFieldViolation(err error, field string) {
+	st, ok := status.FromError(err)
+	s.Require().True(ok, "expected grpc status error, got %v", err)
+	s.Require().Equal(codes.InvalidArgument, st.Code())
+
+	var violations []string
+
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.GetFieldViolations() {
+				violations = append(violations, violation.GetField())
+			}
+		}
+	}
+
+	s.Require().Contains(violations, field)
+}
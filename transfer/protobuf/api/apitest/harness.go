@@ -0,0 +1,354 @@
+// Package apitest runs ExploreServer in-process, so the gRPC API can be tested without any external dependencies.
+package apitest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/PatrykPasterny/dating-engine/internal/config"
+	"github.com/PatrykPasterny/dating-engine/internal/logging"
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+	"github.com/PatrykPasterny/dating-engine/internal/outbox"
+	"github.com/PatrykPasterny/dating-engine/internal/pagination"
+	"github.com/PatrykPasterny/dating-engine/transfer/protobuf/api"
+	pb "github.com/PatrykPasterny/dating-engine/transfer/protobuf/definition"
+)
+
+const bufferSize = 1024 * 1024
+
+// Harness serves ExploreServer over an in-memory connection backed by the given repository, and the admin services
+// over another one, the same way the service serves them on separate ports.
+type Harness struct {
+	t          testing.TB
+	Client     pb.ExploreServiceClient
+	Moderation pb.ModerationServiceClient
+	Webhooks   pb.WebhookServiceClient
+	Health     healthpb.HealthClient
+	// Conn is the connection of the clients of the explore service, it does not reach the admin services.
+	Conn        *grpc.ClientConn
+	Repository  api.MatchRepository
+	Config      *config.Config
+	server      *api.ExploreServer
+	stop        context.CancelFunc
+	served      chan error
+	adminServed chan error
+	stopOnce    sync.Once
+	serveErr    error
+}
+
+// Repository is the repository the harness serves, the events of the users are watched on it and the failed webhook
+// deliveries are kept in it as well.
+type Repository interface {
+	api.MatchRepository
+	api.EventWatcher
+	outbox.DeadLetterStore
+}
+
+// Option changes the configuration the server is created with.
+type Option func(cfg *config.Config)
+
+// WithPageSize sets the default and the maximum page size of the list requests.
+func WithPageSize(pageSize, maxPageSize int64) Option {
+	return func(cfg *config.Config) {
+		cfg.PageSize = pageSize
+		cfg.MaxPageSize = maxPageSize
+	}
+}
+
+// WithSort sets the order the likers are listed in when the request does not specify it.
+func WithSort(sort pagination.Sort, order pagination.Order) Option {
+	return func(cfg *config.Config) {
+		cfg.Pagination.Sort = string(sort)
+		cfg.Pagination.Order = string(order)
+	}
+}
+
+// WithSuperLikeLimit sets how many users a user can super like per day.
+func WithSuperLikeLimit(limit int64) Option {
+	return func(cfg *config.Config) {
+		cfg.SuperLikes.DailyLimit = limit
+	}
+}
+
+// WithUndoWindow sets how long after making the decision the user can undo it.
+func WithUndoWindow(window time.Duration) Option {
+	return func(cfg *config.Config) {
+		cfg.Undo.Window = window
+	}
+}
+
+// WithHideThreshold sets how many users have to report a user to hide the user from the liker lists of the others.
+func WithHideThreshold(threshold int64) Option {
+	return func(cfg *config.Config) {
+		cfg.Reports.HideThreshold = threshold
+	}
+}
+
+// WithWebhook delivers the events of all types to the endpoint signed with the secret, attempting every delivery twice.
+// The harness does not relay the events, but the dead letters kept in the repository can be replayed to the endpoint.
+func WithWebhook(endpoint, secret string) Option {
+	return func(cfg *config.Config) {
+		cfg.Outbox.Publisher = outbox.PublisherWebhook
+		cfg.Outbox.Webhook.Endpoints.LikeCreated = endpoint
+		cfg.Outbox.Webhook.Endpoints.MatchCreated = endpoint
+		cfg.Outbox.Webhook.Endpoints.MatchDissolved = endpoint
+		cfg.Outbox.Webhook.Secret = secret
+		cfg.Outbox.Webhook.MaxAttempts = 2
+	}
+}
+
+// WithShutdownTimeout sets how long the in-flight requests are waited for when the harness is shut down.
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(cfg *config.Config) {
+		cfg.Server.ShutdownTimeout = timeout
+	}
+}
+
+// NewHarness starts the server and connects the client to it, both are stopped when the test finishes.
+func NewHarness(t testing.TB, repository Repository, opts ...Option) *Harness {
+	t.Helper()
+
+	cfg := config.Default()
+	cfg.Server.ShutdownTimeout = time.Second
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	cursorCodec, err := pagination.NewCursorCodec(nil)
+	if err != nil {
+		t.Fatalf("failed creating pagination cursor codec: %v", err)
+	}
+
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	loggingInterceptor := logging.NewInterceptor(logger, 1)
+
+	serverOpts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			loggingInterceptor.UnaryServerInterceptor,
+			api.ValidationUnaryInterceptor,
+		),
+		grpc.ChainStreamInterceptor(
+			loggingInterceptor.StreamServerInterceptor,
+			api.ValidationStreamInterceptor,
+		),
+	}
+
+	grpcServer := grpc.NewServer(serverOpts...)
+	adminServer := grpc.NewServer(serverOpts...)
+
+	var deadLetterReplayer api.DeadLetterReplayer
+
+	if cfg.Outbox.Publisher == outbox.PublisherWebhook {
+		deadLetterReplayer = outbox.NewWebhookPublisher(
+			logger,
+			&http.Client{Timeout: cfg.Outbox.Webhook.Timeout},
+			repository,
+			cfg.WebhookEndpoints(),
+			[]byte(cfg.Outbox.Webhook.Secret),
+			cfg.Outbox.Webhook.MaxAttempts,
+		)
+	}
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(pb.ExploreService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+
+	exploreServer := api.NewExploreServer(
+		logger,
+		cfg,
+		grpcServer,
+		healthServer,
+		repository,
+		repository,
+		deadLetterReplayer,
+		cursorCodec,
+		cfg.PageSize,
+	)
+	pb.RegisterExploreServiceServer(grpcServer, exploreServer)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	pb.RegisterModerationServiceServer(adminServer, exploreServer)
+	pb.RegisterWebhookServiceServer(adminServer, exploreServer)
+
+	listener := bufconn.Listen(bufferSize)
+	adminListener := bufconn.Listen(bufferSize)
+	ctx, stop := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	adminServed := make(chan error, 1)
+
+	go func() {
+		served <- exploreServer.Serve(ctx, listener)
+	}()
+
+	go func() {
+		adminServed <- exploreServer.ServeAdmin(ctx, adminServer, adminListener)
+	}()
+
+	conn, err := dial(listener)
+	if err != nil {
+		stop()
+		t.Fatalf("failed creating grpc client: %v", err)
+	}
+
+	adminConn, err := dial(adminListener)
+	if err != nil {
+		stop()
+		t.Fatalf("failed creating admin grpc client: %v", err)
+	}
+
+	h := &Harness{
+		t:           t,
+		Client:      pb.NewExploreServiceClient(conn),
+		Moderation:  pb.NewModerationServiceClient(adminConn),
+		Webhooks:    pb.NewWebhookServiceClient(adminConn),
+		Health:      healthpb.NewHealthClient(conn),
+		Conn:        conn,
+		Repository:  repository,
+		Config:      cfg,
+		server:      exploreServer,
+		stop:        stop,
+		served:      served,
+		adminServed: adminServed,
+	}
+
+	t.Cleanup(func() {
+		if err := h.Shutdown(); err != nil {
+			t.Errorf("failed serving grpc: %v", err)
+		}
+
+		if err := conn.Close(); err != nil {
+			t.Errorf("failed closing grpc client: %v", err)
+		}
+
+		if err := adminConn.Close(); err != nil {
+			t.Errorf("failed closing admin grpc client: %v", err)
+		}
+	})
+
+	return h
+}
+
+// Shutdown stops the server the same way the service is stopped and returns the error the serving finished with.
+// It is called when the test finishes, so it only has to be called by tests of the shutdown itself.
+func (h *Harness) Shutdown() error {
+	h.stopOnce.Do(func() {
+		h.stop()
+		h.serveErr = errors.Join(<-h.served, <-h.adminServed)
+	})
+
+	return h.serveErr
+}
+
+func dial(listener *bufconn.Listener) (*grpc.ClientConn, error) {
+	return grpc.NewClient(
+		"passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+}
+
+// Reconfigure changes the configuration of the running server the same way reloading the configuration does.
+func (h *Harness) Reconfigure(opts ...Option) {
+	cfg := *h.Config
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	h.Config = &cfg
+	h.server.Reconfigure(h.Config)
+}
+
+// NewUser returns the ID of the user that has not made or received any decision yet.
+func (h *Harness) NewUser() string {
+	h.t.Helper()
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		h.t.Fatalf("failed generating user ID: %v", err)
+	}
+
+	return id.String()
+}
+
+// NewUsers returns the IDs of the given number of new users.
+func (h *Harness) NewUsers(count int) []string {
+	h.t.Helper()
+
+	userIDs := make([]string, 0, count)
+
+	for range count {
+		userIDs = append(userIDs, h.NewUser())
+	}
+
+	return userIDs
+}
+
+// Like records that the actor liked the recipient.
+func (h *Harness) Like(actorID, recipientID string) {
+	h.t.Helper()
+
+	h.decide(actorID, recipientID, model.DecisionLike)
+}
+
+// SuperLike records that the actor super liked the recipient, regardless of the super likes the actor has left.
+func (h *Harness) SuperLike(actorID, recipientID string) {
+	h.t.Helper()
+
+	h.decide(actorID, recipientID, model.DecisionSuperLike)
+}
+
+// Pass records that the actor passed the recipient.
+func (h *Harness) Pass(actorID, recipientID string) {
+	h.t.Helper()
+
+	h.decide(actorID, recipientID, model.DecisionPass)
+}
+
+// Match records that both users liked each other.
+func (h *Harness) Match(firstID, secondID string) {
+	h.t.Helper()
+
+	h.decide(firstID, secondID, model.DecisionLike)
+	h.decide(secondID, firstID, model.DecisionLike)
+}
+
+// LikedBy returns the given number of new users that liked the recipient, in the order they liked them.
+func (h *Harness) LikedBy(recipientID string, count int) []string {
+	h.t.Helper()
+
+	actorIDs := h.NewUsers(count)
+
+	for _, actorID := range actorIDs {
+		h.Like(actorID, recipientID)
+	}
+
+	return actorIDs
+}
+
+func (h *Harness) decide(actorID, recipientID string, decision model.Decision) {
+	h.t.Helper()
+
+	_, err := h.Repository.MakeDecision(context.Background(), actorID, recipientID, decision, math.MaxInt64)
+	if err != nil {
+		h.t.Fatalf("failed making decision of %s on %s: %v", actorID, recipientID, err)
+	}
+}
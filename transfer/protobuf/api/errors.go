@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/PatrykPasterny/dating-engine/internal/repository"
+)
+
+// retryDelay is the delay suggested to the clients before retrying the request that failed with retryable error.
+const retryDelay = 100 * time.Millisecond
+
+// toStatus translates the error into the gRPC status error. Its message never contains the details of the
+// original error, which should be logged instead. Errors that already are gRPC status errors are returned as is.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var invalidInputError *repository.InvalidInputError
+
+	switch {
+	case errors.As(err, &invalidInputError):
+		return invalidArgument(invalidInputError.Field, invalidInputError.Description)
+	case errors.Is(err, repository.ErrInvalidInput):
+		return status.Error(codes.InvalidArgument, "invalid request")
+	case errors.Is(err, repository.ErrNotFound):
+		return status.Error(codes.NotFound, "not found")
+	case errors.Is(err, repository.ErrLimitExceeded):
+		return withDetails(
+			status.New(codes.ResourceExhausted, "daily limit exceeded"),
+			&errdetails.QuotaFailure{
+				Violations: []*errdetails.QuotaFailure_Violation{
+					{
+						Subject:     "super_likes",
+						Description: "all super likes of the day are used",
+					},
+				},
+			},
+		)
+	case errors.Is(err, repository.ErrForbidden):
+		return status.Error(codes.PermissionDenied, "permission denied")
+	case errors.Is(err, repository.ErrConflict):
+		return retryableStatus(codes.Aborted, "conflicting concurrent update")
+	case errors.Is(err, repository.ErrUnavailable):
+		return retryableStatus(codes.Unavailable, "service temporarily unavailable")
+	case errors.Is(err, repository.ErrDeadline), errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "deadline exceeded")
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "request canceled")
+	default:
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+// invalidArgument returns the InvalidArgument status describing the invalid request field.
+func invalidArgument(field, description string) error {
+	return badRequest(&errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: description,
+	})
+}
+
+// badRequest returns the InvalidArgument status describing all invalid request fields.
+func badRequest(violations ...*errdetails.BadRequest_FieldViolation) error {
+	message := "invalid request"
+	if len(violations) == 1 {
+		message = "invalid " + violations[0].Field + ": " + violations[0].Description
+	}
+
+	return withDetails(
+		status.New(codes.InvalidArgument, message),
+		&errdetails.BadRequest{
+			FieldViolations: violations,
+		},
+	)
+}
+
+func retryableStatus(code codes.Code, message string) error {
+	return withDetails(
+		status.New(code, message),
+		&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(retryDelay),
+		},
+	)
+}
+
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) error {
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
@@ -2,13 +2,47 @@ package api
 
 import (
 	"context"
+	"time"
 
 	"github.com/PatrykPasterny/dating-engine/internal/model"
+	"github.com/PatrykPasterny/dating-engine/internal/pagination"
+	"github.com/PatrykPasterny/dating-engine/internal/repository"
 )
 
 type MatchRepository interface {
-	GetLikedUser(ctx context.Context, userID, paginationToken string, limit int64) ([]model.Match, error)
-	GetNewLikedUser(ctx context.Context, userID, paginationToken string, limit int64) ([]model.Match, error)
+	GetLikedUser(ctx context.Context, userID string, page pagination.Page) ([]model.Match, error)
+	GetNewLikedUser(ctx context.Context, userID string, page pagination.Page) ([]model.Match, error)
 	CountLikedUser(ctx context.Context, userID string) (uint64, error)
-	MakeDecision(ctx context.Context, userID, recipientID string, decision bool) (bool, error)
+	MakeDecision(
+		ctx context.Context,
+		userID, recipientID string,
+		decision model.Decision,
+		superLikeLimit int64,
+	) (model.DecisionResult, error)
+	UndoDecision(ctx context.Context, userID string, window time.Duration) (model.DecisionRecord, error)
+	Unmatch(ctx context.Context, userID, matchedUserID string, reason model.UnmatchReason) error
+	BlockUser(ctx context.Context, userID, blockedUserID string) error
+	UnblockUser(ctx context.Context, userID, blockedUserID string) error
+	GetBlockedUsers(ctx context.Context, userID string) ([]model.Block, error)
+	ReportUser(ctx context.Context, report model.Report, hideThreshold int64) (model.ReportResult, error)
+	GetReports(ctx context.Context, state model.ReportState, page pagination.ReportPage) ([]model.Report, error)
+	ResolveReport(
+		ctx context.Context,
+		reportID string,
+		state model.ReportState,
+		note string,
+		hideThreshold int64,
+	) (model.ReportResult, error)
+}
+
+// EventWatcher subscribes to the events of the users, the repository beneath the cache and the metrics implements it.
+type EventWatcher interface {
+	SubscribeEvents(ctx context.Context, userID, resumeToken string) (repository.Subscription, error)
+}
+
+// DeadLetterReplayer delivers the webhook deliveries that failed for good again, it is implemented by the webhook
+// publisher of the outbox.
+type DeadLetterReplayer interface {
+	GetDeadLetters(ctx context.Context, deadLetterIDs []string, limit int64) ([]model.DeadLetter, error)
+	ReplayDeadLetter(ctx context.Context, deadLetter model.DeadLetter) (model.DeadLetter, bool, error)
 }
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/PatrykPasterny/dating-engine/internal/logging"
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+	"github.com/PatrykPasterny/dating-engine/internal/repository"
+	pb "github.com/PatrykPasterny/dating-engine/transfer/protobuf/definition"
+)
+
+var (
+	reportReasons = map[pb.ReportReason]model.ReportReason{
+		pb.ReportReason_REPORT_REASON_SPAM:                  model.ReportReasonSpam,
+		pb.ReportReason_REPORT_REASON_FAKE_PROFILE:          model.ReportReasonFakeProfile,
+		pb.ReportReason_REPORT_REASON_INAPPROPRIATE_CONTENT: model.ReportReasonInappropriateContent,
+		pb.ReportReason_REPORT_REASON_HARASSMENT:            model.ReportReasonHarassment,
+		pb.ReportReason_REPORT_REASON_UNDERAGE:              model.ReportReasonUnderage,
+		pb.ReportReason_REPORT_REASON_OTHER:                 model.ReportReasonOther,
+	}
+	reportStates = map[pb.ReportState]model.ReportState{
+		pb.ReportState_REPORT_STATE_OPEN:      model.ReportStateOpen,
+		pb.ReportState_REPORT_STATE_ACTIONED:  model.ReportStateActioned,
+		pb.ReportState_REPORT_STATE_DISMISSED: model.ReportStateDismissed,
+	}
+)
+
+func (es *ExploreServer) ReportUser(
+	ctx context.Context,
+	request *pb.ReportUserRequest,
+) (*pb.ReportUserResponse, error) {
+	loggerWithFields := es.requestLogger(ctx).With(
+		logging.UserID("reporter_id", request.ReporterUserId),
+		logging.UserID("reported_id", request.ReportedUserId),
+	)
+
+	reason := reportReasons[request.Reason]
+
+	loggerWithFields.DebugContext(ctx, "reporting user", slog.String("reason", string(reason)))
+
+	result, err := es.matchRepository.ReportUser(ctx, model.Report{
+		ReporterUserID: request.ReporterUserId,
+		ReportedUserID: request.ReportedUserId,
+		Reason:         reason,
+		Details:        request.Details,
+		EvidenceRefs:   request.EvidenceRefs,
+	}, es.hideThreshold.Load())
+	if err != nil {
+		loggerWithFields.ErrorContext(ctx, "failed to report user", slog.Any("error", err))
+
+		return nil, toStatus(err)
+	}
+
+	loggerWithFields.DebugContext(
+		ctx,
+		"successfully reported user",
+		slog.String("report_id", result.Report.ID),
+		slog.Bool("hidden_changed", result.HiddenChanged),
+	)
+
+	return &pb.ReportUserResponse{ReportId: result.Report.ID}, nil
+}
+
+func (es *ExploreServer) ListReports(
+	ctx context.Context,
+	request *pb.ListReportsRequest,
+) (*pb.ListReportsResponse, error) {
+	state := reportStates[request.State]
+	loggerWithFields := es.requestLogger(ctx).With(slog.String("state", string(state)))
+
+	loggerWithFields.DebugContext(ctx, "retrieving list of reports")
+
+	page, err := es.newReportPage(request)
+	if err != nil {
+		loggerWithFields.WarnContext(ctx, "received invalid pagination of the list", slog.Any("error", err))
+
+		return nil, err
+	}
+
+	reports, err := es.matchRepository.GetReports(ctx, state, page)
+	if err != nil {
+		loggerWithFields.ErrorContext(ctx, "failed to get reports", slog.Any("error", err))
+
+		return nil, toStatus(err)
+	}
+
+	response := pb.ListReportsResponse{
+		Reports: make([]*pb.Report, 0, len(reports)),
+	}
+
+	for i := range reports {
+		response.Reports = append(response.Reports, newReport(&reports[i]))
+	}
+
+	if len(reports) > 0 {
+		response.NextPaginationToken, err = es.nextReportPaginationToken(state, &reports[len(reports)-1])
+		if err != nil {
+			loggerWithFields.ErrorContext(ctx, "failed to create next pagination token", slog.Any("error", err))
+
+			return nil, toStatus(err)
+		}
+	}
+
+	loggerWithFields.DebugContext(ctx, "successfully retrieved list of reports")
+
+	return &response, nil
+}
+
+func (es *ExploreServer) ResolveReport(
+	ctx context.Context,
+	request *pb.ResolveReportRequest,
+) (*pb.ResolveReportResponse, error) {
+	state := reportStates[request.State]
+	loggerWithFields := es.requestLogger(ctx).With(
+		slog.String("report_id", request.ReportId),
+		slog.String("state", string(state)),
+	)
+
+	loggerWithFields.DebugContext(ctx, "resolving report")
+
+	result, err := es.matchRepository.ResolveReport(
+		ctx,
+		request.ReportId,
+		state,
+		request.Note,
+		es.hideThreshold.Load(),
+	)
+	if errors.Is(err, repository.ErrNotFound) {
+		loggerWithFields.InfoContext(ctx, "report to resolve is not open", slog.Any("error", err))
+
+		return nil, toStatus(err)
+	}
+
+	if err != nil {
+		loggerWithFields.ErrorContext(ctx, "failed to resolve report", slog.Any("error", err))
+
+		return nil, toStatus(err)
+	}
+
+	loggerWithFields.DebugContext(
+		ctx,
+		"successfully resolved report",
+		slog.Bool("hidden_changed", result.HiddenChanged),
+	)
+
+	return &pb.ResolveReportResponse{Report: newReport(&result.Report)}, nil
+}
+
+func newReport(report *model.Report) *pb.Report {
+	converted := &pb.Report{
+		Id:                   report.ID,
+		ReporterUserId:       report.ReporterUserID,
+		ReportedUserId:       report.ReportedUserID,
+		Details:              report.Details,
+		EvidenceRefs:         report.EvidenceRefs,
+		CreatedUnixTimestamp: uint64(report.CreatedAt.Unix()),
+		ResolutionNote:       report.ResolutionNote,
+	}
+
+	for reason, modelReason := range reportReasons {
+		if modelReason == report.Reason {
+			converted.Reason = reason
+		}
+	}
+
+	for state, modelState := range reportStates {
+		if modelState == report.State {
+			converted.State = state
+		}
+	}
+
+	if !report.ResolvedAt.IsZero() {
+		converted.ResolvedUnixTimestamp = uint64(report.ResolvedAt.Unix())
+	}
+
+	return converted
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+	"github.com/PatrykPasterny/dating-engine/internal/pagination"
+	pb "github.com/PatrykPasterny/dating-engine/transfer/protobuf/definition"
+)
+
+var (
+	sorts = map[pb.SortBy]pagination.Sort{
+		pb.SortBy_SORT_BY_ACTOR: pagination.SortByActor,
+		pb.SortBy_SORT_BY_TIME:  pagination.SortByTime,
+	}
+	orders = map[pb.SortOrder]pagination.Order{
+		pb.SortOrder_SORT_ORDER_ASCENDING:  pagination.Ascending,
+		pb.SortOrder_SORT_ORDER_DESCENDING: pagination.Descending,
+	}
+)
+
+// newPage returns the page requested by the user. The page size and order not given in the request fall back to
+// the configured ones, unless the pagination token is given, in which case the order it was issued for is
+// continued. The returned errors are InvalidArgument status errors describing the invalid field.
+func (es *ExploreServer) newPage(request *pb.ListLikedYouRequest) (pagination.Page, error) {
+	settings := es.pageSettings.Load()
+
+	page := pagination.Page{
+		Sort:  settings.sort,
+		Order: settings.order,
+		Limit: settings.pageSize,
+	}
+
+	if request.PageSize != nil && *request.PageSize > 0 {
+		page.Limit = min(int64(*request.PageSize), settings.maxPageSize)
+	}
+
+	sort, sortGiven := sorts[request.SortBy]
+	if !sortGiven && request.SortBy != pb.SortBy_SORT_BY_UNSPECIFIED {
+		return pagination.Page{}, invalidArgument("sort_by", fmt.Sprintf("unknown value %d", request.SortBy))
+	}
+
+	order, orderGiven := orders[request.SortOrder]
+	if !orderGiven && request.SortOrder != pb.SortOrder_SORT_ORDER_UNSPECIFIED {
+		return pagination.Page{}, invalidArgument("sort_order", fmt.Sprintf("unknown value %d", request.SortOrder))
+	}
+
+	if request.PaginationToken == nil || *request.PaginationToken == "" {
+		if sortGiven {
+			page.Sort = sort
+		}
+
+		if orderGiven {
+			page.Order = order
+		}
+
+		return page, nil
+	}
+
+	cursor, err := es.cursorCodec.Decode(*request.PaginationToken)
+	if err != nil {
+		return pagination.Page{}, invalidArgument("pagination_token", err.Error())
+	}
+
+	if cursor.UserID != request.RecipientUserId {
+		return pagination.Page{}, invalidArgument("pagination_token", "issued for another user")
+	}
+
+	if sortGiven && sort != cursor.Sort {
+		return pagination.Page{}, invalidArgument("sort_by", "does not match the pagination token")
+	}
+
+	if orderGiven && order != cursor.Order {
+		return pagination.Page{}, invalidArgument("sort_order", "does not match the pagination token")
+	}
+
+	page.Sort = cursor.Sort
+	page.Order = cursor.Order
+	page.After = &cursor.Position
+
+	return page, nil
+}
+
+// newReportPage returns the page of the reports requested by the moderator. The reports are always listed the
+// oldest first, so only the state the token was issued for has to match the request.
+func (es *ExploreServer) newReportPage(request *pb.ListReportsRequest) (pagination.ReportPage, error) {
+	settings := es.pageSettings.Load()
+
+	page := pagination.ReportPage{
+		Limit: settings.pageSize,
+	}
+
+	if request.PageSize != nil && *request.PageSize > 0 {
+		page.Limit = min(int64(*request.PageSize), settings.maxPageSize)
+	}
+
+	if request.PaginationToken == nil || *request.PaginationToken == "" {
+		return page, nil
+	}
+
+	cursor, err := es.cursorCodec.DecodeReports(*request.PaginationToken)
+	if err != nil {
+		return pagination.ReportPage{}, invalidArgument("pagination_token", err.Error())
+	}
+
+	if cursor.State != string(reportStates[request.State]) {
+		return pagination.ReportPage{}, invalidArgument("state", "does not match the pagination token")
+	}
+
+	page.After = &cursor.Position
+
+	return page, nil
+}
+
+func (es *ExploreServer) nextReportPaginationToken(state model.ReportState, last *model.Report) (*string, error) {
+	cursor := pagination.ReportCursor{
+		State: string(state),
+		Position: pagination.ReportPosition{
+			CreatedAt: last.CreatedAt,
+			ReportID:  last.ID,
+		},
+	}
+
+	token, err := es.cursorCodec.EncodeReports(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("encoding pagination token: %w", err)
+	}
+
+	return &token, nil
+}
+
+func (es *ExploreServer) nextPaginationToken(page pagination.Page, last *model.Match) (*string, error) {
+	cursor := pagination.Cursor{
+		UserID: last.RecipientUserID,
+		Sort:   page.Sort,
+		Order:  page.Order,
+		Position: pagination.Position{
+			SuperLiked:  last.SuperLiked(),
+			LikedAt:     last.UpdatedAt,
+			ActorUserID: last.ActorUserID,
+		},
+	}
+
+	token, err := es.cursorCodec.Encode(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("encoding pagination token: %w", err)
+	}
+
+	return &token, nil
+}
@@ -2,86 +2,229 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
-	"os"
-	"os/signal"
-	"sync"
-	"syscall"
+	"sync/atomic"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
 
 	"github.com/PatrykPasterny/dating-engine/internal/config"
+	"github.com/PatrykPasterny/dating-engine/internal/logging"
+	"github.com/PatrykPasterny/dating-engine/internal/pagination"
 	pb "github.com/PatrykPasterny/dating-engine/transfer/protobuf/definition"
 )
 
+// pageSettings are the defaults of the list requests, they are swapped as a whole when the configuration is
+// reloaded.
+type pageSettings struct {
+	pageSize    int64
+	maxPageSize int64
+	sort        pagination.Sort
+	order       pagination.Order
+}
+
+// decisionSettings limit the decisions of the users, they are swapped as a whole when the configuration is reloaded.
+type decisionSettings struct {
+	superLikeLimit int64
+	undoWindow     time.Duration
+}
+
+// ExploreServer serves the explore service together with the administrative moderation and webhook services, which
+// share the repository and the settings but are served on their own listener, see ServeAdmin.
 type ExploreServer struct {
 	pb.UnimplementedExploreServiceServer
+	pb.UnimplementedModerationServiceServer
+	pb.UnimplementedWebhookServiceServer
 	logger          *slog.Logger
 	grpcServer      *grpc.Server
+	healthServer    *health.Server
 	matchRepository MatchRepository
-	pageSize        int64
-	baseURL         string
+	eventWatcher    EventWatcher
+	// deadLetterReplayer is nil unless the events are delivered to the webhooks.
+	deadLetterReplayer DeadLetterReplayer
+	cursorCodec        *pagination.CursorCodec
+	pageSettings       atomic.Pointer[pageSettings]
+	decisionSettings   atomic.Pointer[decisionSettings]
+	hideThreshold      atomic.Int64
+	baseURL            string
+	shutdownTimeout    time.Duration
+	// watches is done once the server shuts down, ending the streams of the events, which never end on their own.
+	watches     context.Context
+	stopWatches context.CancelFunc
 }
 
 func NewExploreServer(
 	logger *slog.Logger,
 	cfg *config.Config,
 	grpcServer *grpc.Server,
+	healthServer *health.Server,
 	repository MatchRepository,
+	eventWatcher EventWatcher,
+	deadLetterReplayer DeadLetterReplayer,
+	cursorCodec *pagination.CursorCodec,
 	pageSize int64,
 ) *ExploreServer {
-	return &ExploreServer{
-		logger:          logger,
-		grpcServer:      grpcServer,
-		matchRepository: repository,
-		baseURL:         fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port),
-		pageSize:        pageSize,
+	es := &ExploreServer{
+		logger:             logger,
+		grpcServer:         grpcServer,
+		healthServer:       healthServer,
+		matchRepository:    repository,
+		eventWatcher:       eventWatcher,
+		deadLetterReplayer: deadLetterReplayer,
+		cursorCodec:        cursorCodec,
+		baseURL:            fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port),
+		shutdownTimeout:    cfg.Server.ShutdownTimeout,
 	}
+
+	es.watches, es.stopWatches = context.WithCancel(context.Background())
+
+	es.setPageSettings(cfg, pageSize)
+	es.setDecisionSettings(cfg)
+	es.hideThreshold.Store(cfg.Reports.HideThreshold)
+
+	return es
 }
 
-func (es *ExploreServer) Run() {
-	ctx, cancel := context.WithCancel(context.Background())
-	wg := sync.WaitGroup{}
+// Reconfigure applies the reloadable settings of the configuration to the requests received from now on.
+func (es *ExploreServer) Reconfigure(cfg *config.Config) {
+	es.setPageSettings(cfg, cfg.PageSize)
+	es.setDecisionSettings(cfg)
+	es.hideThreshold.Store(cfg.Reports.HideThreshold)
+}
 
-	go func() {
-		signalChan := make(chan os.Signal, 1)
-		signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
-		sig := <-signalChan
+// requestLogger returns the logger scoped to the request by the logging interceptor, falling back to the logger
+// of the server if the interceptor is not installed.
+func (es *ExploreServer) requestLogger(ctx context.Context) *slog.Logger {
+	if logger, ok := logging.FromContext(ctx); ok {
+		return logger
+	}
 
-		es.logger.Info("received signal", slog.String("signal", sig.String()))
-		cancel()
-	}()
+	return es.logger
+}
 
-	wg.Add(1)
+func (es *ExploreServer) setPageSettings(cfg *config.Config, pageSize int64) {
+	es.pageSettings.Store(&pageSettings{
+		pageSize:    pageSize,
+		maxPageSize: max(cfg.MaxPageSize, pageSize),
+		sort:        pagination.Sort(cfg.Pagination.Sort),
+		order:       pagination.Order(cfg.Pagination.Order),
+	})
+}
 
-	go func(running *sync.WaitGroup) {
-		defer running.Done()
+func (es *ExploreServer) setDecisionSettings(cfg *config.Config) {
+	es.decisionSettings.Store(&decisionSettings{
+		superLikeLimit: cfg.SuperLikes.DailyLimit,
+		undoWindow:     cfg.Undo.Window,
+	})
+}
 
-		lis, err := net.Listen("tcp", fmt.Sprintf(es.baseURL))
-		if err != nil {
-			es.logger.Error(
-				"failed to listen",
-				err,
-				slog.String("baseURL", es.baseURL),
-			)
+// Run listens on the configured address and serves the requests until the context is done, see Serve.
+func (es *ExploreServer) Run(ctx context.Context) error {
+	lis, err := net.Listen("tcp", es.baseURL)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", es.baseURL, err)
+	}
+
+	return es.Serve(ctx, lis)
+}
 
-			cancel()
+// Serve serves the requests on the listener until the context is done or serving fails. Once the context is done
+// the server stops accepting new requests and lets the in-flight ones finish, dropping those still running after
+// the shutdown timeout. It returns only after the server has stopped.
+func (es *ExploreServer) Serve(ctx context.Context, lis net.Listener) error {
+	served := make(chan error, 1)
+
+	go func() {
+		served <- es.grpcServer.Serve(lis)
+	}()
+
+	es.logger.Info("explore service up and running", slog.String("address", lis.Addr().String()))
+
+	select {
+	case err := <-served:
+		if err != nil {
+			return fmt.Errorf("serving grpc: %w", err)
 		}
 
-		if err = es.grpcServer.Serve(lis); err != nil {
-			es.logger.Error("failed to serve grpc", err)
+		return nil
+	case <-ctx.Done():
+	}
+
+	es.shutdown()
 
-			cancel()
+	if err := <-served; err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return fmt.Errorf("serving grpc: %w", err)
+	}
+
+	return nil
+}
+
+// ServeAdmin serves the administrative services registered on the admin server on the listener, apart from the
+// explore service, until the context is done or serving fails. It stops the same way as Serve.
+func (es *ExploreServer) ServeAdmin(ctx context.Context, adminServer *grpc.Server, lis net.Listener) error {
+	served := make(chan error, 1)
+
+	go func() {
+		served <- adminServer.Serve(lis)
+	}()
+
+	es.logger.Info("admin services up and running", slog.String("address", lis.Addr().String()))
+
+	select {
+	case err := <-served:
+		if err != nil {
+			return fmt.Errorf("serving admin grpc: %w", err)
 		}
 
-		es.logger.Info("explore service up and running")
-	}(&wg)
+		return nil
+	case <-ctx.Done():
+	}
+
+	es.stop(adminServer)
+
+	if err := <-served; err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return fmt.Errorf("serving admin grpc: %w", err)
+	}
+
+	return nil
+}
+
+func (es *ExploreServer) shutdown() {
+	es.logger.Info("explore service is shutting down", slog.Duration("timeout", es.shutdownTimeout))
 
-	<-ctx.Done()
+	// reporting not serving lets the clients and load balancers move away while the requests are drained
+	if es.healthServer != nil {
+		es.healthServer.Shutdown()
+	}
+
+	es.stopWatches()
+
+	es.stop(es.grpcServer)
+}
 
-	es.grpcServer.Stop()
+// stop lets the in-flight requests of the server finish, dropping those still running after the shutdown timeout.
+func (es *ExploreServer) stop(grpcServer *grpc.Server) {
+	stopped := make(chan struct{})
 
-	wg.Wait()
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(es.shutdownTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-stopped:
+		es.logger.Info("grpc server stopped gracefully")
+	case <-timer.C:
+		es.logger.Warn("shutdown timeout exceeded, dropping in-flight requests")
+
+		grpcServer.Stop()
+		<-stopped
+	}
 }
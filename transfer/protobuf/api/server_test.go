@@ -0,0 +1,195 @@
+package api_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/PatrykPasterny/dating-engine/internal/config"
+	"github.com/PatrykPasterny/dating-engine/internal/repository"
+	"github.com/PatrykPasterny/dating-engine/transfer/protobuf/api"
+	"github.com/PatrykPasterny/dating-engine/transfer/protobuf/api/apitest"
+	pb "github.com/PatrykPasterny/dating-engine/transfer/protobuf/definition"
+)
+
+// blockingRepository blocks counting the likers until it is released or the request is canceled, simulating
+// a long running request.
+type blockingRepository struct {
+	*repository.MemoryRepository
+	started  chan struct{}
+	released chan struct{}
+}
+
+func newBlockingRepository() *blockingRepository {
+	return &blockingRepository{
+		MemoryRepository: repository.NewMemoryRepository(),
+		started:          make(chan struct{}),
+		released:         make(chan struct{}),
+	}
+}
+
+func (br *blockingRepository) CountLikedUser(ctx context.Context, userID string) (uint64, error) {
+	close(br.started)
+
+	select {
+	case <-br.released:
+		return br.MemoryRepository.CountLikedUser(ctx, userID)
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+func TestRunFailsWhenListeningFails(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Server.Host = "localhost"
+	cfg.Server.Port = "not-a-port"
+
+	exploreServer := api.NewExploreServer(
+		slog.New(slog.NewJSONHandler(io.Discard, nil)),
+		cfg,
+		grpc.NewServer(),
+		health.NewServer(),
+		repository.NewMemoryRepository(),
+		nil,
+		nil,
+		nil,
+		cfg.PageSize,
+	)
+
+	err := exploreServer.Run(context.Background())
+	require.Error(t, err)
+}
+
+func TestShutdownWaitsForInFlightRequests(t *testing.T) {
+	blockingRepository := newBlockingRepository()
+	harness := apitest.NewHarness(t, blockingRepository, apitest.WithShutdownTimeout(5*time.Second))
+
+	responses := make(chan error, 1)
+
+	go func() {
+		_, err := harness.Client.CountLikedYou(context.Background(), &pb.CountLikedYouRequest{
+			RecipientUserId: harness.NewUser(),
+		})
+		responses <- err
+	}()
+
+	<-blockingRepository.started
+
+	shutdown := make(chan error, 1)
+
+	go func() {
+		shutdown <- harness.Shutdown()
+	}()
+
+	select {
+	case <-shutdown:
+		t.Fatal("server stopped before the in-flight request finished")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(blockingRepository.released)
+
+	require.NoError(t, <-responses)
+	require.NoError(t, <-shutdown)
+}
+
+func TestShutdownEndsWatchedLikes(t *testing.T) {
+	harness := apitest.NewHarness(t, repository.NewMemoryRepository(), apitest.WithShutdownTimeout(5*time.Second))
+
+	stream, err := harness.Client.WatchLikes(context.Background(), &pb.WatchLikesRequest{UserId: harness.NewUser()})
+	require.NoError(t, err)
+
+	_, err = stream.Header()
+	require.NoError(t, err)
+
+	// the stream never ends on its own, so the shutdown would otherwise wait for the whole timeout
+	start := time.Now()
+	require.NoError(t, harness.Shutdown())
+	require.Less(t, time.Since(start), time.Second)
+
+	_, err = stream.Recv()
+	require.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestHealthReportsServing(t *testing.T) {
+	harness := apitest.NewHarness(t, repository.NewMemoryRepository())
+
+	for _, service := range []string{"", pb.ExploreService_ServiceDesc.ServiceName} {
+		response, err := harness.Health.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		require.Equal(t, healthpb.HealthCheckResponse_SERVING, response.GetStatus())
+	}
+}
+
+func TestHealthReportsNotServingDuringShutdown(t *testing.T) {
+	blockingRepository := newBlockingRepository()
+	harness := apitest.NewHarness(t, blockingRepository, apitest.WithShutdownTimeout(5*time.Second))
+
+	watchCtx, cancelWatch := context.WithCancel(context.Background())
+	defer cancelWatch()
+
+	watch, err := harness.Health.Watch(watchCtx, &healthpb.HealthCheckRequest{
+		Service: pb.ExploreService_ServiceDesc.ServiceName,
+	})
+	require.NoError(t, err)
+
+	response, err := watch.Recv()
+	require.NoError(t, err)
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, response.GetStatus())
+
+	responses := make(chan error, 1)
+
+	go func() {
+		_, err := harness.Client.CountLikedYou(context.Background(), &pb.CountLikedYouRequest{
+			RecipientUserId: harness.NewUser(),
+		})
+		responses <- err
+	}()
+
+	<-blockingRepository.started
+
+	shutdown := make(chan error, 1)
+
+	go func() {
+		shutdown <- harness.Shutdown()
+	}()
+
+	response, err = watch.Recv()
+	require.NoError(t, err)
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, response.GetStatus())
+
+	// the watch is an in-flight request as well, the server waits for it before stopping
+	cancelWatch()
+	close(blockingRepository.released)
+
+	require.NoError(t, <-responses)
+	require.NoError(t, <-shutdown)
+}
+
+func TestShutdownDropsRequestsAfterTimeout(t *testing.T) {
+	blockingRepository := newBlockingRepository()
+	harness := apitest.NewHarness(t, blockingRepository, apitest.WithShutdownTimeout(50*time.Millisecond))
+
+	responses := make(chan error, 1)
+
+	go func() {
+		_, err := harness.Client.CountLikedYou(context.Background(), &pb.CountLikedYouRequest{
+			RecipientUserId: harness.NewUser(),
+		})
+		responses <- err
+	}()
+
+	<-blockingRepository.started
+
+	require.NoError(t, harness.Shutdown())
+	require.Equal(t, codes.Unavailable, status.Code(<-responses))
+}
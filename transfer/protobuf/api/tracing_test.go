@@ -0,0 +1,56 @@
+package api_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/PatrykPasterny/dating-engine/internal/repository"
+	"github.com/PatrykPasterny/dating-engine/transfer/protobuf/api/apitest"
+	pb "github.com/PatrykPasterny/dating-engine/transfer/protobuf/definition"
+)
+
+func TestRequestContinuesCallerTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	harness := apitest.NewHarness(t, repository.NewMemoryRepository())
+
+	const (
+		traceID  = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentID = "00f067aa0ba902b7"
+	)
+
+	ctx := metadata.AppendToOutgoingContext(
+		context.Background(),
+		"traceparent", "00-"+traceID+"-"+parentID+"-01",
+	)
+
+	_, err := harness.Client.CountLikedYou(ctx, &pb.CountLikedYouRequest{RecipientUserId: harness.NewUser()})
+	require.NoError(t, err)
+
+	// the server span is ended only after the response is sent
+	require.Eventually(t, func() bool {
+		return len(recorder.Ended()) == 1
+	}, time.Second, time.Millisecond)
+
+	spans := recorder.Ended()
+	require.Equal(t, "explore.ExploreService/CountLikedYou", spans[0].Name())
+	require.Equal(t, traceID, spans[0].SpanContext().TraceID().String())
+	require.Equal(t, parentID, spans[0].Parent().SpanID().String())
+}
@@ -0,0 +1,398 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+
+	pb "github.com/PatrykPasterny/dating-engine/transfer/protobuf/definition"
+)
+
+const (
+	// maxPaginationTokenLength is well above the length of the tokens issued by the service.
+	maxPaginationTokenLength = 512
+	// maxTextLength is the number of characters the free text of the reports and their resolutions can have.
+	maxTextLength = 2000
+	// maxEvidenceRefs is the number of evidence references a report can have.
+	maxEvidenceRefs = 10
+	// maxEvidenceRefLength is the number of characters of every evidence reference.
+	maxEvidenceRefLength = 512
+	// maxResumeTokenLength is well above the length of the resume tokens of the change streams.
+	maxResumeTokenLength = 1024
+	// maxDeadLetterIDs is the number of dead letters a single request can replay.
+	maxDeadLetterIDs = 100
+)
+
+// ValidationUnaryInterceptor rejects the requests that are not valid with the InvalidArgument status describing
+// all invalid fields, before they reach the handlers.
+func ValidationUnaryInterceptor(
+	ctx context.Context,
+	request any,
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if violations := validateRequest(request); len(violations) > 0 {
+		return nil, badRequest(violations...)
+	}
+
+	return handler(ctx, request)
+}
+
+// ValidationStreamInterceptor rejects the invalid requests of the streams like ValidationUnaryInterceptor does, as
+// they are received.
+func ValidationStreamInterceptor(
+	server any,
+	stream grpc.ServerStream,
+	_ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	return handler(server, &validatedStream{ServerStream: stream})
+}
+
+type validatedStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatedStream) RecvMsg(message any) error {
+	if err := s.ServerStream.RecvMsg(message); err != nil {
+		return err
+	}
+
+	if violations := validateRequest(message); len(violations) > 0 {
+		return badRequest(violations...)
+	}
+
+	return nil
+}
+
+func validateRequest(request any) []*errdetails.BadRequest_FieldViolation {
+	switch r := request.(type) {
+	case *pb.ListLikedYouRequest:
+		return validateListLikedYouRequest(r)
+	case *pb.CountLikedYouRequest:
+		return validateCountLikedYouRequest(r)
+	case *pb.PutDecisionRequest:
+		return validatePutDecisionRequest(r)
+	case *pb.UndoDecisionRequest:
+		return validateUndoDecisionRequest(r)
+	case *pb.UnmatchRequest:
+		return validateUnmatchRequest(r)
+	case *pb.BlockUserRequest:
+		return validateBlockRequest(r.UserId, r.BlockedUserId)
+	case *pb.UnblockUserRequest:
+		return validateBlockRequest(r.UserId, r.BlockedUserId)
+	case *pb.ListBlockedRequest:
+		return validateListBlockedRequest(r)
+	case *pb.ReportUserRequest:
+		return validateReportUserRequest(r)
+	case *pb.ListReportsRequest:
+		return validateListReportsRequest(r)
+	case *pb.ResolveReportRequest:
+		return validateResolveReportRequest(r)
+	case *pb.WatchLikesRequest:
+		return validateWatchLikesRequest(r)
+	case *pb.ReplayDeadLettersRequest:
+		return validateReplayDeadLettersRequest(r)
+	default:
+		return nil
+	}
+}
+
+func validateListLikedYouRequest(request *pb.ListLikedYouRequest) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+
+	violations = appendUserIDViolation(violations, "recipient_user_id", request.RecipientUserId)
+
+	if request.PaginationToken != nil {
+		violations = appendPaginationTokenViolation(violations, "pagination_token", *request.PaginationToken)
+	}
+
+	return violations
+}
+
+func validateCountLikedYouRequest(request *pb.CountLikedYouRequest) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+
+	violations = appendUserIDViolation(violations, "recipient_user_id", request.RecipientUserId)
+
+	return violations
+}
+
+func validatePutDecisionRequest(request *pb.PutDecisionRequest) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+
+	violations = appendUserIDViolation(violations, "actor_user_id", request.ActorUserId)
+	violations = appendUserIDViolation(violations, "recipient_user_id", request.RecipientUserId)
+
+	if request.ActorUserId != "" && request.ActorUserId == request.RecipientUserId {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "recipient_user_id",
+			Description: "must differ from actor_user_id",
+		})
+	}
+
+	if _, ok := pb.Decision_name[int32(request.Decision)]; !ok {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "decision",
+			Description: "unknown decision",
+		})
+	}
+
+	return violations
+}
+
+func validateUndoDecisionRequest(request *pb.UndoDecisionRequest) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+
+	violations = appendUserIDViolation(violations, "actor_user_id", request.ActorUserId)
+
+	return violations
+}
+
+func validateUnmatchRequest(request *pb.UnmatchRequest) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+
+	violations = appendUserIDViolation(violations, "user_id", request.UserId)
+	violations = appendUserIDViolation(violations, "matched_user_id", request.MatchedUserId)
+
+	if request.UserId != "" && request.UserId == request.MatchedUserId {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "matched_user_id",
+			Description: "must differ from user_id",
+		})
+	}
+
+	if _, ok := unmatchReasons[request.Reason]; !ok {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "reason",
+			Description: "must be a known reason",
+		})
+	}
+
+	return violations
+}
+
+// validateBlockRequest validates the users of BlockUserRequest and UnblockUserRequest, which have the same fields.
+func validateBlockRequest(userID, blockedUserID string) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+
+	violations = appendUserIDViolation(violations, "user_id", userID)
+	violations = appendUserIDViolation(violations, "blocked_user_id", blockedUserID)
+
+	if userID != "" && userID == blockedUserID {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "blocked_user_id",
+			Description: "must differ from user_id",
+		})
+	}
+
+	return violations
+}
+
+func validateListBlockedRequest(request *pb.ListBlockedRequest) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+
+	violations = appendUserIDViolation(violations, "user_id", request.UserId)
+
+	return violations
+}
+
+func validateWatchLikesRequest(request *pb.WatchLikesRequest) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+
+	violations = appendUserIDViolation(violations, "user_id", request.UserId)
+
+	// the token is verified by the repository, which issued it
+	if request.ResumeToken != nil && (*request.ResumeToken == "" || len(*request.ResumeToken) > maxResumeTokenLength) {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "resume_token",
+			Description: fmt.Sprintf("must have between 1 and %d characters", maxResumeTokenLength),
+		})
+	}
+
+	return violations
+}
+
+func validateReportUserRequest(request *pb.ReportUserRequest) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+
+	violations = appendUserIDViolation(violations, "reporter_user_id", request.ReporterUserId)
+	violations = appendUserIDViolation(violations, "reported_user_id", request.ReportedUserId)
+
+	if request.ReporterUserId != "" && request.ReporterUserId == request.ReportedUserId {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "reported_user_id",
+			Description: "must differ from reporter_user_id",
+		})
+	}
+
+	if _, ok := reportReasons[request.Reason]; !ok {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "reason",
+			Description: "must be a known reason",
+		})
+	}
+
+	violations = appendTextViolation(violations, "details", request.Details, maxTextLength)
+
+	if len(request.EvidenceRefs) > maxEvidenceRefs {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "evidence_refs",
+			Description: fmt.Sprintf("must have at most %d references", maxEvidenceRefs),
+		})
+	}
+
+	for i, ref := range request.EvidenceRefs {
+		field := fmt.Sprintf("evidence_refs[%d]", i)
+
+		if ref == "" {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       field,
+				Description: "must not be empty",
+			})
+		}
+
+		violations = appendTextViolation(violations, field, ref, maxEvidenceRefLength)
+	}
+
+	return violations
+}
+
+func validateListReportsRequest(request *pb.ListReportsRequest) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+
+	if _, ok := reportStates[request.State]; !ok && request.State != pb.ReportState_REPORT_STATE_UNSPECIFIED {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "state",
+			Description: "must be a known state",
+		})
+	}
+
+	if request.PaginationToken != nil {
+		violations = appendPaginationTokenViolation(violations, "pagination_token", *request.PaginationToken)
+	}
+
+	return violations
+}
+
+func validateResolveReportRequest(request *pb.ResolveReportRequest) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+
+	// the reports are identified by UUIDs just like the users
+	violations = appendUserIDViolation(violations, "report_id", request.ReportId)
+
+	if request.State != pb.ReportState_REPORT_STATE_ACTIONED && request.State != pb.ReportState_REPORT_STATE_DISMISSED {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "state",
+			Description: "must be either actioned or dismissed",
+		})
+	}
+
+	violations = appendTextViolation(violations, "note", request.Note, maxTextLength)
+
+	return violations
+}
+
+func validateReplayDeadLettersRequest(request *pb.ReplayDeadLettersRequest) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+
+	if len(request.DeadLetterIds) > maxDeadLetterIDs {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "dead_letter_ids",
+			Description: fmt.Sprintf("must have at most %d IDs", maxDeadLetterIDs),
+		})
+	}
+
+	seen := make(map[string]bool, len(request.DeadLetterIds))
+
+	for i, deadLetterID := range request.DeadLetterIds {
+		field := fmt.Sprintf("dead_letter_ids[%d]", i)
+
+		// the dead letters are identified by UUIDs just like the users
+		violations = appendUserIDViolation(violations, field, deadLetterID)
+
+		if seen[deadLetterID] {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       field,
+				Description: "must not repeat another ID",
+			})
+		}
+
+		seen[deadLetterID] = true
+	}
+
+	if request.Limit != nil && len(request.DeadLetterIds) > 0 {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "limit",
+			Description: "must not be set together with dead_letter_ids",
+		})
+	}
+
+	return violations
+}
+
+func appendTextViolation(
+	violations []*errdetails.BadRequest_FieldViolation,
+	field, text string,
+	maxLength int,
+) []*errdetails.BadRequest_FieldViolation {
+	if !utf8.ValidString(text) || utf8.RuneCountInString(text) > maxLength {
+		return append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: fmt.Sprintf("must be valid text of at most %d characters", maxLength),
+		})
+	}
+
+	return violations
+}
+
+// appendUserIDViolation requires the user ID to be a UUID in its canonical form, which is how the user IDs are
+// stored, so the same user can't be referred to by differently formatted IDs.
+func appendUserIDViolation(
+	violations []*errdetails.BadRequest_FieldViolation,
+	field, userID string,
+) []*errdetails.BadRequest_FieldViolation {
+	if userID == "" {
+		return append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: "must not be empty",
+		})
+	}
+
+	if id, err := uuid.Parse(userID); err != nil || id.String() != userID {
+		return append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: "must be a lowercase UUID in its canonical form",
+		})
+	}
+
+	return violations
+}
+
+// appendPaginationTokenViolation only checks whether the token could have been issued by the service, the token
+// itself is verified when it is decoded.
+func appendPaginationTokenViolation(
+	violations []*errdetails.BadRequest_FieldViolation,
+	field, token string,
+) []*errdetails.BadRequest_FieldViolation {
+	if len(token) > maxPaginationTokenLength {
+		return append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: "is too long",
+		})
+	}
+
+	if _, err := base64.RawURLEncoding.DecodeString(token); err != nil {
+		return append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: "is malformed",
+		})
+	}
+
+	return violations
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/PatrykPasterny/dating-engine/internal/logging"
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+	"github.com/PatrykPasterny/dating-engine/internal/repository"
+	pb "github.com/PatrykPasterny/dating-engine/transfer/protobuf/definition"
+)
+
+var likeEventKinds = map[model.EventKind]pb.LikeEventKind{
+	model.EventKindLike:    pb.LikeEventKind_LIKE_EVENT_KIND_LIKE,
+	model.EventKindMatch:   pb.LikeEventKind_LIKE_EVENT_KIND_MATCH,
+	model.EventKindUnmatch: pb.LikeEventKind_LIKE_EVENT_KIND_UNMATCH,
+}
+
+// WatchLikes streams the events of the user until the client stops watching or the server shuts down. The headers
+// are sent once the user is subscribed, so no event made after the client received them is missed.
+func (es *ExploreServer) WatchLikes(request *pb.WatchLikesRequest, stream pb.ExploreService_WatchLikesServer) error {
+	ctx := stream.Context()
+	loggerWithFields := es.requestLogger(ctx).With(logging.UserID("user_id", request.UserId))
+
+	loggerWithFields.DebugContext(ctx, "watching likes", slog.Bool("resumed", request.ResumeToken != nil))
+
+	subscription, err := es.eventWatcher.SubscribeEvents(ctx, request.UserId, request.GetResumeToken())
+	if errors.Is(err, repository.ErrNotFound) {
+		loggerWithFields.InfoContext(ctx, "events after resume token are no longer kept", slog.Any("error", err))
+
+		return status.Error(codes.OutOfRange, "resume token expired, list the likes to catch up")
+	}
+
+	if err != nil {
+		loggerWithFields.ErrorContext(ctx, "failed to subscribe to likes", slog.Any("error", err))
+
+		return toStatus(err)
+	}
+
+	defer func() {
+		if err := subscription.Close(context.Background()); err != nil {
+			loggerWithFields.WarnContext(ctx, "failed to unsubscribe from likes", slog.Any("error", err))
+		}
+	}()
+
+	if err = stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stop := context.AfterFunc(es.watches, cancel)
+	defer stop()
+
+	for {
+		event, resumeToken, err := subscription.Next(watchCtx)
+
+		switch {
+		case err == nil:
+		case ctx.Err() != nil:
+			loggerWithFields.DebugContext(ctx, "client stopped watching likes")
+
+			return toStatus(ctx.Err())
+		case watchCtx.Err() != nil:
+			return retryableStatus(codes.Unavailable, "server is shutting down, resume watching")
+		case errors.Is(err, repository.ErrNotFound):
+			loggerWithFields.InfoContext(ctx, "watching likes fell behind", slog.Any("error", err))
+
+			return status.Error(codes.OutOfRange, "events are no longer kept, list the likes to catch up")
+		default:
+			loggerWithFields.ErrorContext(ctx, "failed to watch likes", slog.Any("error", err))
+
+			return toStatus(err)
+		}
+
+		if err = stream.Send(newLikeEvent(event, resumeToken)); err != nil {
+			return err
+		}
+	}
+}
+
+func newLikeEvent(event model.Event, resumeToken string) *pb.LikeEvent {
+	return &pb.LikeEvent{
+		Kind:          likeEventKinds[event.Kind],
+		OtherUserId:   event.OtherUserID,
+		SuperLiked:    event.SuperLiked,
+		UnixTimestamp: uint64(event.At.Unix()),
+		ResumeToken:   resumeToken,
+	}
+}
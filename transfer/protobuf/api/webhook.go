@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/PatrykPasterny/dating-engine/internal/model"
+	pb "github.com/PatrykPasterny/dating-engine/transfer/protobuf/definition"
+)
+
+func (es *ExploreServer) ReplayDeadLetters(
+	ctx context.Context,
+	request *pb.ReplayDeadLettersRequest,
+) (*pb.ReplayDeadLettersResponse, error) {
+	loggerWithFields := es.requestLogger(ctx).With(slog.Int("requested_dead_letters", len(request.DeadLetterIds)))
+
+	loggerWithFields.DebugContext(ctx, "replaying dead letters")
+
+	if es.deadLetterReplayer == nil {
+		loggerWithFields.InfoContext(ctx, "dead letters cannot be replayed without the webhook delivery")
+
+		return nil, status.Error(codes.FailedPrecondition, "webhook delivery is not enabled")
+	}
+
+	settings := es.pageSettings.Load()
+	limit := settings.pageSize
+
+	if request.Limit != nil && *request.Limit > 0 {
+		limit = min(int64(*request.Limit), settings.maxPageSize)
+	}
+
+	deadLetters, err := es.deadLetterReplayer.GetDeadLetters(ctx, request.DeadLetterIds, limit)
+	if err != nil {
+		loggerWithFields.ErrorContext(ctx, "failed to get dead letters", slog.Any("error", err))
+
+		return nil, toStatus(err)
+	}
+
+	// the requested IDs are unique, so some of them are missing if fewer dead letters are found
+	if len(deadLetters) < len(request.DeadLetterIds) {
+		loggerWithFields.InfoContext(ctx, "some of the dead letters to replay do not exist")
+
+		return nil, status.Error(codes.NotFound, "no dead letter to replay")
+	}
+
+	response := pb.ReplayDeadLettersResponse{
+		Replays: make([]*pb.DeadLetterReplay, 0, len(deadLetters)),
+	}
+
+	for _, deadLetter := range deadLetters {
+		replayed, delivered, err := es.deadLetterReplayer.ReplayDeadLetter(ctx, deadLetter)
+		if err != nil {
+			loggerWithFields.ErrorContext(
+				ctx,
+				"failed to replay dead letter",
+				slog.String("dead_letter_id", deadLetter.ID),
+				slog.Any("error", err),
+			)
+
+			return nil, toStatus(err)
+		}
+
+		response.Replays = append(response.Replays, newDeadLetterReplay(&replayed, delivered))
+	}
+
+	loggerWithFields.DebugContext(ctx, "successfully replayed dead letters", slog.Int("replayed", len(deadLetters)))
+
+	return &response, nil
+}
+
+func newDeadLetterReplay(deadLetter *model.DeadLetter, delivered bool) *pb.DeadLetterReplay {
+	return &pb.DeadLetterReplay{
+		DeadLetterId: deadLetter.ID,
+		EventId:      deadLetter.Event.ID,
+		EventType:    string(deadLetter.Event.Type),
+		Endpoint:     deadLetter.Endpoint,
+		Delivered:    delivered,
+		Attempts:     uint64(deadLetter.Attempts),
+		LastError:    deadLetter.LastError,
+	}
+}